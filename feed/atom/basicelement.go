@@ -48,8 +48,9 @@ func (b *BasicElement) Name() xml.Name {
 func (b *BasicElement) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if b.depth.IsRoot() {
 		b.name = el.Name
-		b.Extension = extension.InitExtension(b.name.Local, b.Extension.Manager)
+		b.Extension = extension.InitExtension(el.Path, b.Extension.Manager, "basicelement")
 
+		b.InheritLang(el)
 		for _, attr := range el.Attr {
 			if !b.ProcessAttr(attr) {
 				b.Extension.ProcessAttr(attr, b)
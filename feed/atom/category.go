@@ -23,13 +23,13 @@ func NewCategory() *Category {
 	c := Category{depth: xmlutils.NewDepthWatcher()}
 
 	c.Term = xmlutils.NewElement("term", "", xmlutils.Nop)
-	c.Term.SetOccurence(xmlutils.NewOccurence("term", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	c.Term.SetOccurence(xmlutils.NewOccurence("term", existsAndIsUnique))
 
 	c.Scheme = xmlutils.NewElement("scheme", "", IsValidIRI)
-	c.Scheme.SetOccurence(xmlutils.NewOccurence("scheme", xmlutils.UniqueValidator(AttributeDuplicated)))
+	c.Scheme.SetOccurence(xmlutils.NewOccurence("scheme", isUnique))
 
 	c.Label = xmlutils.NewElement("label", "", xmlutils.Nop)
-	c.Label.SetOccurence(xmlutils.NewOccurence("label", xmlutils.UniqueValidator(AttributeDuplicated)))
+	c.Label.SetOccurence(xmlutils.NewOccurence("label", isUnique))
 
 	c.InitCommonAttributes()
 
@@ -52,7 +52,9 @@ func (c *Category) reset() {
 
 func (c *Category) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if c.depth.IsRoot() {
+		c.Extension = extension.InitExtension(el.Path, c.Extension.Manager)
 		c.reset()
+		c.InheritLang(el)
 		for _, attr := range el.Attr {
 			switch attr.Name.Space {
 			case xmlutils.XML_NS:
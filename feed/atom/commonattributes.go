@@ -10,14 +10,16 @@ import (
 type CommonAttributes struct {
 	Base xmlutils.Element
 	Lang xmlutils.Element
+
+	effectiveLang string
 }
 
 func (c *CommonAttributes) InitCommonAttributes() {
 	c.Base = xmlutils.NewElement("base", "", IsValidIRI)
-	c.Base.SetOccurence(xmlutils.NewOccurence("base", xmlutils.UniqueValidator(AttributeDuplicated)))
+	c.Base.SetOccurence(xmlutils.NewOccurence("base", isUnique))
 
-	c.Lang = xmlutils.NewElement("lang", "", xmlutils.Nop)
-	c.Lang.SetOccurence(xmlutils.NewOccurence("lang", xmlutils.UniqueValidator(AttributeDuplicated)))
+	c.Lang = xmlutils.NewElement("lang", "", xmlutils.IsValidLanguage)
+	c.Lang.SetOccurence(xmlutils.NewOccurence("lang", isUnique))
 
 }
 
@@ -41,6 +43,23 @@ func (c *CommonAttributes) ProcessAttr(attr xml.Attr) bool {
 	return false
 }
 
+// InheritLang records the language of the element started by el: its xml:lang,
+// or the one inherited from its closest ancestor
+func (c *CommonAttributes) InheritLang(el xmlutils.StartElement) {
+	c.effectiveLang = el.Lang
+}
+
+// EffectiveLang returns the language of the element, inherited from its
+// ancestors when it has no xml:lang. It is empty when unknown.
+func (c *CommonAttributes) EffectiveLang() string {
+	return c.effectiveLang
+}
+
+// Direction returns the writing direction of the language of the element
+func (c *CommonAttributes) Direction() xmlutils.Direction {
+	return xmlutils.LanguageDirection(c.effectiveLang)
+}
+
 func (c *CommonAttributes) ResetAttr() {
 	c.Base.Reset()
 	c.Lang.Reset()
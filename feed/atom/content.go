@@ -64,6 +64,8 @@ func (c *Content) HasReadableContent() bool {
 
 func (c *Content) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	c.reset()
+	c.Extension = extension.InitExtension(el.Path, c.Extension.Manager)
+	c.InheritLang(el)
 	for _, attr := range el.Attr {
 		switch attr.Name.Space {
 		case xmlutils.XML_NS:
@@ -103,7 +105,7 @@ func (c *Content) ProcessEndElement(el xml.EndElement) (xmlutils.Visitor, xmluti
 		}
 		return nil, nil
 	}
-	return c.Parent, nil
+	return c.Parent, c.validate()
 }
 
 func (c *Content) ProcessCharData(el xml.CharData) (xmlutils.Visitor, xmlutils.ParserError) {
@@ -37,7 +37,9 @@ func NewDateExt(manager extension.Manager) *Date {
 
 func (d *Date) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if d.depth.IsRoot() {
+		d.Extension = extension.InitExtension(el.Path, d.Extension.Manager, "date")
 		d.ResetAttr()
+		d.InheritLang(el)
 		for _, attr := range el.Attr {
 			if !d.ProcessAttr(attr) {
 				d.Extension.ProcessAttr(attr, d)
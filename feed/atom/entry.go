@@ -17,7 +17,7 @@ type Entry struct {
 	Content      *Content
 	Contributors []*Person
 	Id           *Id
-	Links        []*Link
+	Links        Links
 	Published    *Date
 	Rights       *TextConstruct
 	Source       *Source
@@ -84,12 +84,12 @@ func (e *Entry) init() {
 	e.InitCommonAttributes()
 
 	e.Occurences = xmlutils.NewOccurenceCollection(
-		xmlutils.NewOccurence("content", xmlutils.UniqueValidator(AttributeDuplicated)),
+		xmlutils.NewOccurence("content", isUnique),
 		xmlutils.NewOccurence("id", xmlutils.ExistsAndUniqueValidator(MissingId, IdDuplicated)),
-		xmlutils.NewOccurence("published", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("rights", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("source", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("summary", xmlutils.UniqueValidator(AttributeDuplicated)),
+		xmlutils.NewOccurence("published", isUnique),
+		xmlutils.NewOccurence("rights", isUnique),
+		xmlutils.NewOccurence("source", isUnique),
+		xmlutils.NewOccurence("summary", isUnique),
 		xmlutils.NewOccurence("title", xmlutils.ExistsAndUniqueValidator(MissingTitle, TitleDuplicated)),
 		xmlutils.NewOccurence("updated", xmlutils.ExistsAndUniqueValidator(MissingDate, AttributeDuplicated)),
 	)
@@ -103,7 +103,9 @@ func (e *Entry) reset() {
 
 func (e *Entry) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if e.depth.IsRoot() {
+		e.Extension = extension.InitExtension(el.Path, e.Extension.Manager)
 		e.reset()
+		e.InheritLang(el)
 		for _, attr := range el.Attr {
 			if !e.ProcessAttr(attr) {
 				e.Extension.ProcessAttr(attr, e)
@@ -223,7 +225,7 @@ func (e *Entry) validateLinks(err *utils.ErrorAggregator) {
 	hasAlternateRel := false
 
 	for _, link := range e.Links {
-		if link.Rel.Value == "alternate" {
+		if NormalizeRel(link.Rel.Value) == "alternate" {
 			hasAlternateRel = true
 			s := link.Type.Value + link.HrefLang.Value
 			unique := true
@@ -245,3 +247,34 @@ func (e *Entry) validateLinks(err *utils.ErrorAggregator) {
 		err.NewError(xmlutils.NewError(NoContentOrAlternateLink, "Entry should have either a Content element or a Link with alternate type"))
 	}
 }
+
+// LinksByRel returns the links of e whose relation is rel, see NormalizeRel
+func (e *Entry) LinksByRel(rel string) Links {
+	return e.Links.ByRel(rel)
+}
+
+// Self returns the link to e itself, or nil
+func (e *Entry) Self() *Link {
+	return e.Links.First("self")
+}
+
+// Alternate returns the first alternate link of e in language lang and of
+// media type typ, see Links.Alternate
+func (e *Entry) Alternate(lang, typ string) *Link {
+	return e.Links.Alternate(lang, typ)
+}
+
+// Edit returns the link to edit e (Atom Publishing Protocol), or nil
+func (e *Entry) Edit() *Link {
+	return e.Links.First("edit")
+}
+
+// Replies returns the first link to the replies to e (RFC 4685), or nil
+func (e *Entry) Replies() *Link {
+	return e.Links.First("replies")
+}
+
+// Enclosures returns the links to the files attached to e
+func (e *Entry) Enclosures() Links {
+	return e.Links.ByRel("enclosure")
+}
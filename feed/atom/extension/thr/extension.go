@@ -18,44 +18,27 @@ func AddToManager(manager *extension.Manager) {
 }
 
 func GetInReplyTo(e *atom.Entry) (*InReplyTo, bool) {
-
-	itf, ok := e.Extension.Store.GetItf(_inreplyto)
-	if !ok {
-		return nil, false
-	}
-	i, ok := itf.(*InReplyTo)
-	return i, ok
+	v, err := extension.Get[*InReplyTo](&e.Extension.Store, _inreplyto)
+	return v, err == nil
 }
 
 func GetTotal(e *atom.Entry) (*atom.BasicElement, bool) {
-
-	itf, ok := e.Extension.Store.GetItf(_total)
-	if !ok {
-		return nil, false
-	}
-	i, ok := itf.(*atom.BasicElement)
-	return i, ok
+	v, err := extension.Get[*atom.BasicElement](&e.Extension.Store, _total)
+	return v, err == nil
 }
 
 func GetCount(l *atom.Link) (*Count, bool) {
-	itf, ok := l.Extension.Store.GetItf(_count)
-	if !ok {
-		return nil, false
-	}
-
-	i, ok := itf.(*Count)
-
-	return i, ok
-
+	v, err := extension.Get[*Count](&l.Extension.Store, _count)
+	return v, err == nil
 }
 
 func GetUpdated(l *atom.Link) (*Updated, bool) {
-	itf, ok := l.Extension.Store.GetItf(_updated)
-	if !ok {
-		return nil, false
-	}
-	i, ok := itf.(*Updated)
-
-	return i, ok
+	v, err := extension.Get[*Updated](&l.Extension.Store, _updated)
+	return v, err == nil
+}
 
+// GetAllInReplyTo returns every thr:in-reply-to of the entry
+func GetAllInReplyTo(e *atom.Entry) []*InReplyTo {
+	v, _ := extension.GetAll[*InReplyTo](&e.Extension.Store, _inreplyto)
+	return v
 }
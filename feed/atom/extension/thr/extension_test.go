@@ -1,6 +1,7 @@
 package thr
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -319,3 +320,33 @@ func TestThrEntryBasic(t *testing.T) {
 	}
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestThrGenericGetters(t *testing.T) {
+	entry := NewTestThrEntry(nil, "44", "", "http://www.example.org/entries/1", "", "")
+
+	inReplyTo, err := extension.Get[*InReplyTo](&entry.Extension.Store, _inreplyto)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if inReplyTo.Ref.Value != "http://www.example.org/entries/1" {
+		t.Errorf("THR in-reply-to ref do not match '%s' (expected) vs '%s'", "http://www.example.org/entries/1", inReplyTo.Ref.Value)
+	}
+
+	if _, err := extension.Get[*InReplyTo](&entry.Extension.Store, _total); !errors.Is(err, extension.ErrWrongType) {
+		t.Errorf("THR total read as in-reply-to should return ErrWrongType, got %v", err)
+	}
+
+	link := NewTestThrLink("", "10")
+	if _, err := extension.Get[*Updated](&link.Extension.Store, _updated); !errors.Is(err, extension.ErrNotFound) {
+		t.Errorf("missing THR updated should return ErrNotFound, got %v", err)
+	}
+
+	counts, err := extension.GetAll[extension.Attr](&link.Extension.Store, _count)
+	if err != nil || len(counts) != 1 || counts[0].String() != "10" {
+		t.Errorf("THR count should be readable with GetAll, got %v", err)
+	}
+
+	if _, ok := GetUpdated(link); ok {
+		t.Errorf("GetUpdated should report a missing updated attribute")
+	}
+}
@@ -0,0 +1,10 @@
+package youtube
+
+import (
+	"github.com/jloup/utils"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+var (
+	RatingNotValid = utils.InitFlag(&xmlutils.ErrorFlagCounter, "RatingNotValid")
+)
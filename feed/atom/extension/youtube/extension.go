@@ -1,3 +1,4 @@
+// Package youtube implements youtube extension (http://www.youtube.com/xml/schemas/2015) for atom feed, media:group (http://search.yahoo.com/mrss/) included
 package youtube
 
 import (
@@ -13,40 +14,30 @@ func AddToManager(manager *extension.Manager) {
 	manager.AddElementExtension("entry", _channelId, newChannelIdElement, xmlutils.UniqueValidator(atom.AttributeDuplicated))
 	manager.AddElementExtension("feed", _channelId, newChannelIdElement, xmlutils.UniqueValidator(atom.AttributeDuplicated))
 	manager.AddElementExtension("feed", _playlistId, newPlaylistIdElement, xmlutils.UniqueValidator(atom.AttributeDuplicated))
+	manager.AddElementExtension("entry", _mediaGroup, newMediaGroupElement, xmlutils.UniqueValidator(atom.AttributeDuplicated))
 }
 
 func GetVideoId(e *atom.Entry) (*atom.BasicElement, bool) {
-	itf, ok := e.Extension.Store.GetItf(_videoId)
-	if !ok {
-		return nil, false
-	}
-	i, ok := itf.(*atom.BasicElement)
-	return i, ok
+	v, err := extension.Get[*atom.BasicElement](&e.Extension.Store, _videoId)
+	return v, err == nil
 }
 
 func GetEntryChannelId(e *atom.Entry) (*atom.BasicElement, bool) {
-	itf, ok := e.Extension.Store.GetItf(_channelId)
-	if !ok {
-		return nil, false
-	}
-	i, ok := itf.(*atom.BasicElement)
-	return i, ok
+	v, err := extension.Get[*atom.BasicElement](&e.Extension.Store, _channelId)
+	return v, err == nil
 }
 
 func GetFeedChannelId(f *atom.Feed) (*atom.BasicElement, bool) {
-	itf, ok := f.Extension.Store.GetItf(_channelId)
-	if !ok {
-		return nil, false
-	}
-	i, ok := itf.(*atom.BasicElement)
-	return i, ok
+	v, err := extension.Get[*atom.BasicElement](&f.Extension.Store, _channelId)
+	return v, err == nil
 }
 
 func GetFeedPlaylistId(f *atom.Feed) (*atom.BasicElement, bool) {
-	itf, ok := f.Extension.Store.GetItf(_playlistId)
-	if !ok {
-		return nil, false
-	}
-	i, ok := itf.(*atom.BasicElement)
-	return i, ok
+	v, err := extension.Get[*atom.BasicElement](&f.Extension.Store, _playlistId)
+	return v, err == nil
+}
+
+func GetMediaGroup(e *atom.Entry) (*MediaGroup, bool) {
+	v, err := extension.Get[*MediaGroup](&e.Extension.Store, _mediaGroup)
+	return v, err == nil
 }
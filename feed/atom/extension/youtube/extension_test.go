@@ -1,7 +1,10 @@
 package youtube
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/jloup/xml/feed/atom"
@@ -215,3 +218,173 @@ func TestYoutubeFeedBasic(t *testing.T) {
 	}
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestYoutubeGenericGetters(t *testing.T) {
+	entry := NewTestYoutubeEntry("aF4JE5XmkfY", "")
+
+	videoId, err := extension.Get[*atom.BasicElement](&entry.Extension.Store, _videoId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if videoId.String() != "aF4JE5XmkfY" {
+		t.Errorf("youtube videoId do not match '%s' (expected) vs '%s'", "aF4JE5XmkfY", videoId.String())
+	}
+
+	if _, err := extension.Get[*atom.BasicElement](&entry.Extension.Store, _channelId); !errors.Is(err, extension.ErrNotFound) {
+		t.Errorf("missing youtube channelId should return ErrNotFound, got %v", err)
+	}
+
+	if _, err := extension.Get[*atom.Link](&entry.Extension.Store, _videoId); !errors.Is(err, extension.ErrWrongType) {
+		t.Errorf("youtube videoId read as a link should return ErrWrongType, got %v", err)
+	}
+
+	if _, ok := GetEntryChannelId(entry); ok {
+		t.Errorf("GetEntryChannelId should report a missing channelId")
+	}
+}
+
+func TestYoutubeMediaGroupFixture(t *testing.T) {
+	f, err := os.Open("testdata/videos.xml")
+	if err != nil {
+		t.Fatalf("cannot open fixture: %s", err)
+	}
+	defer f.Close()
+
+	feed := testYoutubeFeedConstructor().(*atom.Feed)
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+	// youtube feeds have no updated date and their entries neither summary nor content
+	checker.DisableErrorChecking("feed", atom.MissingDate)
+	checker.DisableErrorChecking("entry", atom.MissingSummary)
+
+	if err := xmlutils.Walk(f, feed, &checker, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf("2 entries expected, got %v", len(feed.Entries))
+	}
+
+	group, ok := GetMediaGroup(feed.Entries[0])
+	if !ok {
+		t.Fatalf("media:group not found")
+	}
+
+	if group.Title != "First video" {
+		t.Errorf("media:title do not match '%s' (expected) vs '%s'", "First video", group.Title)
+	}
+
+	if group.Description != "A description\non two lines." {
+		t.Errorf("media:description do not match, got '%s'", group.Description)
+	}
+
+	if len(group.Contents) != 1 || group.Contents[0] != (MediaContent{"https://www.youtube.com/v/aF4JE5XmkfY?version=3", "application/x-shockwave-flash", 640, 390}) {
+		t.Errorf("media:content do not match, got %v", group.Contents)
+	}
+
+	if len(group.Thumbnails) != 1 || group.Thumbnails[0] != (Thumbnail{"https://i1.ytimg.com/vi/aF4JE5XmkfY/hqdefault.jpg", 480, 360}) {
+		t.Errorf("media:thumbnail do not match, got %v", group.Thumbnails)
+	}
+
+	if group.Community == nil || group.Community.StarRating == nil || group.Community.Statistics == nil {
+		t.Fatalf("media:community not complete: %v", group.Community)
+	}
+
+	if *group.Community.StarRating != (StarRating{Count: 1520, Average: 4.91, Min: 1, Max: 5}) {
+		t.Errorf("media:starRating do not match, got %v", *group.Community.StarRating)
+	}
+
+	if group.Community.Statistics.Views != 84211 {
+		t.Errorf("media:statistics views do not match %v (expected) vs %v", 84211, group.Community.Statistics.Views)
+	}
+
+	group, ok = GetMediaGroup(feed.Entries[1])
+	if !ok || group.Description != "" || group.Community.StarRating.Count != 0 || group.Community.Statistics.Views != 0 {
+		t.Errorf("unrated video media:group do not match, got %v", group)
+	}
+}
+
+func TestYoutubeMediaGroupKeywords(t *testing.T) {
+	manager := extension.Manager{}
+	AddToManager(&manager)
+	entry := atom.NewEntryExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`<entry xmlns:media="http://search.yahoo.com/mrss/">
+	  <id>yt:video:aF4JE5XmkfY</id>
+	  <media:group><media:keywords>go, parsing ,,feeds</media:keywords></media:group>
+	</entry>`), entry, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	group, ok := GetMediaGroup(entry)
+	if !ok {
+		t.Fatalf("media:group not found")
+	}
+
+	if strings.Join(group.Keywords, "|") != "go|parsing|feeds" {
+		t.Errorf("media:keywords do not match, got %v", group.Keywords)
+	}
+}
+
+func TestYoutubeMediaGroupErrors(t *testing.T) {
+	var testdata = []struct {
+		XML           string
+		ExpectedError xmlutils.ParserError
+	}{
+		{`<media:group><media:thumbnail url="https://i1.ytimg.com/vi/x/hqdefault.jpg" width="wide" height="360"/></media:group>`,
+			xmlutils.NewError(atom.NotPositiveNumber, ""),
+		},
+		{`<media:group><media:thumbnail width="480" height="360"/></media:group>`,
+			xmlutils.NewError(atom.MissingAttribute, ""),
+		},
+		{`<media:group><media:content url="/v/x" width="640" height="390"/></media:group>`,
+			xmlutils.NewError(atom.IriNotAbsolute, ""),
+		},
+		{`<media:group><media:community><media:starRating count="3" average="7.5" min="1" max="5"/></media:community></media:group>`,
+			xmlutils.NewError(RatingNotValid, ""),
+		},
+		{`<media:group><media:community><media:starRating count="3" average="high" min="1" max="5"/></media:community></media:group>`,
+			xmlutils.NewError(RatingNotValid, ""),
+		},
+		{`<media:group><media:community><media:statistics views="-1"/></media:community></media:group>`,
+			xmlutils.NewError(atom.NotPositiveNumber, ""),
+		},
+		{`<media:group><media:title>a</media:title><media:title>b</media:title></media:group>`,
+			xmlutils.NewError(atom.AttributeDuplicated, ""),
+		},
+		{`<media:group><media:title>a</media:title></media:group><media:group><media:title>b</media:title></media:group>`,
+			xmlutils.NewError(atom.AttributeDuplicated, ""),
+		},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, test := range testdata {
+		testcase := xmlutils.TestVisitor{
+			XML: `<entry xmlns:media="http://search.yahoo.com/mrss/">
+			  <id>yt:video:aF4JE5XmkfY</id>` + test.XML + `</entry>`,
+			ExpectedError: test.ExpectedError,
+			VisitorConstructor: func() xmlutils.Visitor {
+				manager := extension.Manager{}
+				AddToManager(&manager)
+				return atom.NewEntryExt(manager)
+			},
+			Validator: func(actual, expected xmlutils.Visitor) error { return nil },
+		}
+
+		custom := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+		custom.EnableErrorChecking("entry", atom.AttributeDuplicated)
+		custom.EnableErrorChecking("group", atom.AttributeDuplicated)
+		custom.EnableErrorChecking(xmlutils.AllError, atom.NotPositiveNumber, atom.MissingAttribute, atom.IriNotAbsolute, RatingNotValid)
+		testcase.CustomError = &custom
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
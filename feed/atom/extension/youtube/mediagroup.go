@@ -0,0 +1,266 @@
+package youtube
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jloup/utils"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/extension"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// MediaNS is the Media RSS namespace used by youtube for media:group
+const MediaNS = "http://search.yahoo.com/mrss/"
+
+var _mediaGroup = xml.Name{Space: MediaNS, Local: "group"}
+
+// MediaGroup is the media:group element of a youtube entry
+type MediaGroup struct {
+	Title       string
+	Description string
+	// Keywords is media:keywords, split on commas
+	Keywords   []string
+	Contents   []MediaContent
+	Thumbnails []Thumbnail
+	Community  *Community
+
+	Parent     xmlutils.Visitor
+	depth      xmlutils.DepthWatcher
+	current    string
+	text       string
+	errors     []xmlutils.ParserError
+	Occurences xmlutils.OccurenceCollection
+}
+
+// MediaContent is a media:content, e.g. the video player
+type MediaContent struct {
+	Url    string
+	Type   string
+	Width  int
+	Height int
+}
+
+// Thumbnail is a media:thumbnail
+type Thumbnail struct {
+	Url    string
+	Width  int
+	Height int
+}
+
+// Community is media:community; StarRating and Statistics are nil when absent
+type Community struct {
+	StarRating *StarRating
+	Statistics *Statistics
+}
+
+// StarRating is media:starRating
+type StarRating struct {
+	Count   int
+	Average float64
+	Min     int
+	Max     int
+}
+
+// Statistics is media:statistics
+type Statistics struct {
+	Views int
+}
+
+func newMediaGroup() *MediaGroup {
+	m := MediaGroup{depth: xmlutils.NewDepthWatcher()}
+
+	m.Occurences = xmlutils.NewOccurenceCollection(
+		xmlutils.NewOccurence("title", xmlutils.UniqueValidator(atom.AttributeDuplicated)),
+		xmlutils.NewOccurence("description", xmlutils.UniqueValidator(atom.AttributeDuplicated)),
+		xmlutils.NewOccurence("keywords", xmlutils.UniqueValidator(atom.AttributeDuplicated)),
+		xmlutils.NewOccurence("community", xmlutils.UniqueValidator(atom.AttributeDuplicated)),
+		xmlutils.NewOccurence("starrating", xmlutils.UniqueValidator(atom.AttributeDuplicated)),
+		xmlutils.NewOccurence("statistics", xmlutils.UniqueValidator(atom.AttributeDuplicated)),
+	)
+
+	return &m
+}
+
+func newMediaGroupElement() extension.Element {
+	return newMediaGroup()
+}
+
+func (m *MediaGroup) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	m.depth.Down()
+	m.current = ""
+
+	if el.Name.Space != MediaNS {
+		return m, nil
+	}
+
+	switch el.Name.Local {
+	case "title", "description", "keywords":
+		m.Occurences.Inc(el.Name.Local)
+		m.current = el.Name.Local
+		m.text = ""
+
+	case "content":
+		c := MediaContent{}
+		for _, attr := range el.Attr {
+			switch attr.Name.Local {
+			case "url":
+				c.Url = attr.Value
+			case "type":
+				c.Type = attr.Value
+			case "width":
+				c.Width = m.parseNumber("content", attr)
+			case "height":
+				c.Height = m.parseNumber("content", attr)
+			}
+		}
+		m.checkUrl("content", c.Url)
+		m.Contents = append(m.Contents, c)
+
+	case "thumbnail":
+		t := Thumbnail{}
+		for _, attr := range el.Attr {
+			switch attr.Name.Local {
+			case "url":
+				t.Url = attr.Value
+			case "width":
+				t.Width = m.parseNumber("thumbnail", attr)
+			case "height":
+				t.Height = m.parseNumber("thumbnail", attr)
+			}
+		}
+		m.checkUrl("thumbnail", t.Url)
+		m.Thumbnails = append(m.Thumbnails, t)
+
+	case "community":
+		m.Occurences.Inc("community")
+		m.Community = &Community{}
+
+	case "starrating":
+		m.Occurences.Inc("starrating")
+		r := StarRating{}
+		for _, attr := range el.Attr {
+			switch attr.Name.Local {
+			case "count":
+				r.Count = m.parseNumber("starRating", attr)
+			case "min":
+				r.Min = m.parseNumber("starRating", attr)
+			case "max":
+				r.Max = m.parseNumber("starRating", attr)
+			case "average":
+				var err error
+				if r.Average, err = strconv.ParseFloat(attr.Value, 64); err != nil {
+					m.addError(xmlutils.NewError(RatingNotValid, fmt.Sprintf("starRating's average '%s' is not a number", attr.Value)))
+				}
+			}
+		}
+		// unrated videos come with average="0.00" min="1"
+		if r.Min > r.Max || (r.Count > 0 && (r.Average < float64(r.Min) || r.Average > float64(r.Max))) {
+			m.addError(xmlutils.NewError(RatingNotValid, fmt.Sprintf("starRating's average %v is not in [%v, %v]", r.Average, r.Min, r.Max)))
+		}
+		m.community().StarRating = &r
+
+	case "statistics":
+		m.Occurences.Inc("statistics")
+		s := Statistics{}
+		for _, attr := range el.Attr {
+			if attr.Name.Local == "views" {
+				s.Views = m.parseNumber("statistics", attr)
+			}
+		}
+		m.community().Statistics = &s
+	}
+
+	return m, nil
+}
+
+func (m *MediaGroup) community() *Community {
+	if m.Community == nil {
+		m.Community = &Community{}
+	}
+	return m.Community
+}
+
+func (m *MediaGroup) parseNumber(parent string, attr xml.Attr) int {
+	n, err := strconv.Atoi(attr.Value)
+	if err != nil || n < 0 {
+		m.addError(xmlutils.NewError(atom.NotPositiveNumber, fmt.Sprintf("%s's %s '%s' should be a positive number", parent, attr.Name.Local, attr.Value)))
+		return 0
+	}
+	return n
+}
+
+func (m *MediaGroup) checkUrl(parent, url string) {
+	if url == "" {
+		m.addError(xmlutils.NewError(atom.MissingAttribute, fmt.Sprintf("%s's url should exist", parent)))
+		return
+	}
+
+	if err := atom.IsAbsoluteIRI(parent+"'s url", url); err != nil {
+		m.addError(err)
+	}
+}
+
+func (m *MediaGroup) ProcessEndElement(el xml.EndElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	switch m.current {
+	case "title":
+		m.Title = strings.TrimSpace(m.text)
+	case "description":
+		m.Description = strings.TrimSpace(m.text)
+	case "keywords":
+		m.Keywords = nil
+		for _, keyword := range strings.Split(m.text, ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				m.Keywords = append(m.Keywords, keyword)
+			}
+		}
+	}
+	m.current = ""
+
+	if m.depth.Up() == xmlutils.RootLevel {
+		return m.Parent, m.Validate()
+	}
+
+	return m, nil
+}
+
+func (m *MediaGroup) ProcessCharData(el xml.CharData) (xmlutils.Visitor, xmlutils.ParserError) {
+	if m.current != "" {
+		m.text += string(el)
+	}
+	return m, nil
+}
+
+func (m *MediaGroup) addError(err xmlutils.ParserError) {
+	m.errors = append(m.errors, err)
+}
+
+func (m *MediaGroup) Validate() xmlutils.ParserError {
+	error := utils.NewErrorAggregator()
+
+	for _, err := range m.errors {
+		error.NewError(err)
+	}
+	xmlutils.ValidateOccurenceCollection("group", &error, m.Occurences)
+
+	return error.ErrorObject()
+}
+
+func (m *MediaGroup) Name() xml.Name {
+	return _mediaGroup
+}
+
+func (m *MediaGroup) String() string {
+	return m.Title
+}
+
+// Categories implements extension.Categorizer with the keywords of m
+func (m *MediaGroup) Categories() []string {
+	return m.Keywords
+}
+
+func (m *MediaGroup) SetParent(p xmlutils.Visitor) {
+	m.Parent = p
+}
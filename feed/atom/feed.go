@@ -17,7 +17,7 @@ type Feed struct {
 	Generator    *Generator
 	Icon         *Icon
 	Id           *Id
-	Links        []*Link
+	Links        Links
 	Logo         *Logo
 	Rights       *TextConstruct
 	Subtitle     *TextConstruct
@@ -83,12 +83,12 @@ func (f *Feed) init() {
 	f.InitCommonAttributes()
 
 	f.Occurences = xmlutils.NewOccurenceCollection(
-		xmlutils.NewOccurence("generator", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("icon", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("logo", xmlutils.UniqueValidator(AttributeDuplicated)),
+		xmlutils.NewOccurence("generator", isUnique),
+		xmlutils.NewOccurence("icon", isUnique),
+		xmlutils.NewOccurence("logo", isUnique),
 		xmlutils.NewOccurence("id", xmlutils.ExistsAndUniqueValidator(MissingId, IdDuplicated)),
-		xmlutils.NewOccurence("rights", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("subtitle", xmlutils.UniqueValidator(AttributeDuplicated)),
+		xmlutils.NewOccurence("rights", isUnique),
+		xmlutils.NewOccurence("subtitle", isUnique),
 		xmlutils.NewOccurence("title", xmlutils.ExistsAndUniqueValidator(MissingTitle, TitleDuplicated)),
 		xmlutils.NewOccurence("updated", xmlutils.ExistsAndUniqueValidator(MissingDate, AttributeDuplicated)),
 	)
@@ -102,7 +102,9 @@ func (f *Feed) reset() {
 
 func (f *Feed) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if f.depth.IsRoot() {
+		f.Extension = extension.InitExtension(el.Path, f.Extension.Manager)
 		f.reset()
+		f.InheritLang(el)
 		for _, attr := range el.Attr {
 			if !f.ProcessAttr(attr) {
 				f.Extension.ProcessAttr(attr, f)
@@ -254,7 +256,7 @@ func (f *Feed) validateLinks(err *utils.ErrorAggregator) {
 	hasSelf := false
 
 	for _, link := range f.Links {
-		if link.Rel.Value == "alternate" {
+		if NormalizeRel(link.Rel.Value) == "alternate" {
 			s := link.Type.Value + link.HrefLang.Value
 			unique := true
 
@@ -268,7 +270,7 @@ func (f *Feed) validateLinks(err *utils.ErrorAggregator) {
 			if unique {
 				combinations = append(combinations, s)
 			}
-		} else if link.Rel.Value == "self" {
+		} else if NormalizeRel(link.Rel.Value) == "self" {
 			hasSelf = true
 		}
 	}
@@ -277,3 +279,48 @@ func (f *Feed) validateLinks(err *utils.ErrorAggregator) {
 		err.NewError(xmlutils.NewError(MissingSelfLink, "Feed must have a link with rel attribute set to 'self'"))
 	}
 }
+
+// LinksByRel returns the links of f whose relation is rel, see NormalizeRel
+func (f *Feed) LinksByRel(rel string) Links {
+	return f.Links.ByRel(rel)
+}
+
+// Self returns the link to f itself, or nil
+func (f *Feed) Self() *Link {
+	return f.Links.First("self")
+}
+
+// Alternate returns the first alternate link of f in language lang and of
+// media type typ, see Links.Alternate
+func (f *Feed) Alternate(lang, typ string) *Link {
+	return f.Links.Alternate(lang, typ)
+}
+
+// Hub returns the first WebSub hub of f, or nil
+func (f *Feed) Hub() *Link {
+	return f.Links.First("hub")
+}
+
+// Next returns the link to the next page of a paged feed (RFC 5005), or nil
+func (f *Feed) Next() *Link {
+	return f.Links.First("next")
+}
+
+// Previous returns the link to the previous page of a paged feed (RFC 5005),
+// or nil. "prev" is used when "previous" is missing.
+func (f *Feed) Previous() *Link {
+	if link := f.Links.First("previous"); link != nil {
+		return link
+	}
+	return f.Links.First("prev")
+}
+
+// First returns the link to the first page of a paged feed (RFC 5005), or nil
+func (f *Feed) First() *Link {
+	return f.Links.First("first")
+}
+
+// Last returns the link to the last page of a paged feed (RFC 5005), or nil
+func (f *Feed) Last() *Link {
+	return f.Links.First("last")
+}
@@ -2,8 +2,10 @@ package atom
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/jloup/xml/feed/extension"
 	xmlutils "github.com/jloup/xml/utils"
 )
 
@@ -1147,3 +1149,197 @@ func TestFeedBasic(t *testing.T) {
 
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestFeedExtensionSelectors(t *testing.T) {
+	ext := extension.NewDefinition("http://example.org/ext",
+		extension.Field{Name: "nick", Parents: []string{"entry/author"}},
+		extension.Field{Name: "license", Parents: []string{"entry/link"}},
+		extension.Field{Name: "precision", Attr: true, Parents: []string{"entry/updated"}},
+		extension.Field{Name: "note", Attr: true, Parents: []string{"/feed/title"}},
+	)
+
+	manager := extension.Manager{}
+	if err := manager.AddDefinition(ext); err != nil {
+		t.Fatalf("cannot add definition: %s", err)
+	}
+
+	f := NewFeedExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+  <feed xmlns="http://www.w3.org/2005/Atom" xmlns:ext="http://example.org/ext">
+    <title ext:note="feed">feed title</title>
+    <id>tag:example.org,2003:3</id>
+    <updated ext:precision="day">2005-07-31T12:29:29Z</updated>
+    <link rel="self" href="http://example.org/feed.atom"/>
+    <author><name>Feed Author</name><ext:nick>feed</ext:nick></author>
+    <entry>
+      <title ext:note="entry">entry title</title>
+      <summary>entry summary</summary>
+      <id>tag:example.org,2003:3.2397</id>
+      <updated ext:precision="second">2005-07-31T12:29:29Z</updated>
+      <link href="http://example.org/2005/04/02/atom"><ext:license>cc-by</ext:license></link>
+      <author><name>Mark Pilgrim</name><ext:nick>mark</ext:nick></author>
+      <contributor><name>Sam Ruby</name><ext:nick>sam</ext:nick></contributor>
+    </entry>
+  </feed>`), f, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	nick := ext.StringField("nick")
+	license := ext.StringField("license")
+	precision := ext.StringField("precision")
+	note := ext.StringField("note")
+
+	entry := f.Entries[0]
+	if v, ok := nick.Get(&entry.Authors[0].Extension.Store); !ok || v != "mark" {
+		t.Errorf("entry author nick should be 'mark', got '%s' (%v)", v, ok)
+	}
+	if _, ok := nick.Get(&entry.Contributors[0].Extension.Store); ok {
+		t.Errorf("entry contributor should not get the entry/author extension")
+	}
+	if _, ok := nick.Get(&f.Authors[0].Extension.Store); ok {
+		t.Errorf("feed author should not get the entry/author extension")
+	}
+
+	if v, ok := license.Get(&entry.Links[0].Extension.Store); !ok || v != "cc-by" {
+		t.Errorf("entry link license should be 'cc-by', got '%s' (%v)", v, ok)
+	}
+
+	if v, ok := precision.Get(&entry.Updated.Extension.Store); !ok || v != "second" {
+		t.Errorf("entry updated precision should be 'second', got '%s' (%v)", v, ok)
+	}
+	if _, ok := precision.Get(&f.Updated.Extension.Store); ok {
+		t.Errorf("feed updated should not get the entry/updated extension")
+	}
+
+	if v, ok := note.Get(&f.Title.Extension.Store); !ok || v != "feed" {
+		t.Errorf("feed title note should be 'feed', got '%s' (%v)", v, ok)
+	}
+	if _, ok := note.Get(&entry.Title.Extension.Store); ok {
+		t.Errorf("entry title should not get the /feed/title extension")
+	}
+}
+
+func TestFeedExtensionLegacySelectors(t *testing.T) {
+	ext := extension.NewDefinition("http://example.org/ext",
+		extension.Field{Name: "nick", Parents: []string{"person"}},
+		extension.Field{Name: "precision", Attr: true, Parents: []string{"date"}},
+		extension.Field{Name: "note", Attr: true, Parents: []string{"textconstruct"}},
+	)
+
+	manager := extension.Manager{}
+	if err := manager.AddDefinition(ext); err != nil {
+		t.Fatalf("cannot add definition: %s", err)
+	}
+
+	f := NewFeedExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+  <feed xmlns="http://www.w3.org/2005/Atom" xmlns:ext="http://example.org/ext">
+    <title ext:note="feed">feed title</title>
+    <id>tag:example.org,2003:3</id>
+    <updated ext:precision="day">2005-07-31T12:29:29Z</updated>
+    <link rel="self" href="http://example.org/feed.atom"/>
+    <author><name>Feed Author</name><ext:nick>feed</ext:nick></author>
+    <entry>
+      <title>entry title</title>
+      <summary ext:note="summary">entry summary</summary>
+      <id>tag:example.org,2003:3.2397</id>
+      <updated>2005-07-31T12:29:29Z</updated>
+      <published ext:precision="second">2005-07-31T12:29:29Z</published>
+      <link href="http://example.org/2005/04/02/atom"/>
+      <contributor><name>Sam Ruby</name><ext:nick>sam</ext:nick></contributor>
+    </entry>
+  </feed>`), f, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	nick := ext.StringField("nick")
+	precision := ext.StringField("precision")
+	note := ext.StringField("note")
+
+	entry := f.Entries[0]
+	if v, ok := nick.Get(&f.Authors[0].Extension.Store); !ok || v != "feed" {
+		t.Errorf("feed author nick should be 'feed', got '%s' (%v)", v, ok)
+	}
+	if v, ok := nick.Get(&entry.Contributors[0].Extension.Store); !ok || v != "sam" {
+		t.Errorf("entry contributor nick should be 'sam', got '%s' (%v)", v, ok)
+	}
+
+	if v, ok := precision.Get(&f.Updated.Extension.Store); !ok || v != "day" {
+		t.Errorf("feed updated precision should be 'day', got '%s' (%v)", v, ok)
+	}
+	if v, ok := precision.Get(&entry.Published.Extension.Store); !ok || v != "second" {
+		t.Errorf("entry published precision should be 'second', got '%s' (%v)", v, ok)
+	}
+
+	if v, ok := note.Get(&f.Title.Extension.Store); !ok || v != "feed" {
+		t.Errorf("feed title note should be 'feed', got '%s' (%v)", v, ok)
+	}
+	if v, ok := note.Get(&entry.Summary.Extension.Store); !ok || v != "summary" {
+		t.Errorf("entry summary note should be 'summary', got '%s' (%v)", v, ok)
+	}
+}
+
+func TestFeedLang(t *testing.T) {
+	f := NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+  <feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en-US">
+    <title>feed title</title>
+    <id>tag:example.org,2003:3</id>
+    <updated>2005-07-31T12:29:29Z</updated>
+    <link rel="self" href="http://example.org/feed.atom"/>
+    <author><name>Feed Author</name></author>
+    <entry xml:lang="ar">
+      <title>entry title</title>
+      <summary xml:lang="fr">entry summary</summary>
+      <content>entry content</content>
+      <id>tag:example.org,2003:3.2397</id>
+      <updated>2005-07-31T12:29:29Z</updated>
+    </entry>
+  </feed>`), f, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entry := f.Entries[0]
+	var testdata = []struct {
+		Name      string
+		Lang      string
+		Direction xmlutils.Direction
+		Actual    *CommonAttributes
+	}{
+		{"feed", "en-US", xmlutils.LeftToRight, &f.CommonAttributes},
+		{"feed title", "en-US", xmlutils.LeftToRight, &f.Title.CommonAttributes},
+		{"author", "en-US", xmlutils.LeftToRight, &f.Authors[0].CommonAttributes},
+		{"entry title", "ar", xmlutils.RightToLeft, &entry.Title.CommonAttributes},
+		{"entry summary", "fr", xmlutils.LeftToRight, &entry.Summary.CommonAttributes},
+		{"entry content", "ar", xmlutils.RightToLeft, &entry.Content.CommonAttributes},
+	}
+
+	for _, test := range testdata {
+		if test.Actual.EffectiveLang() != test.Lang || test.Actual.Direction() != test.Direction {
+			t.Errorf("%s: expected %s (%s), got %s (%s)", test.Name, test.Lang, test.Direction, test.Actual.EffectiveLang(), test.Actual.Direction())
+		}
+	}
+
+	testcase := xmlutils.TestVisitor{
+		XML:                `<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en_US"><title>t</title><id>tag:example.org,2003:3</id><updated>2005-07-31T12:29:29Z</updated><link rel="self" href="http://example.org/feed.atom"/><author><name>a</name></author></feed>`,
+		ExpectedError:      xmlutils.NewError(xmlutils.LanguageNotValid, ""),
+		VisitorConstructor: func() xmlutils.Visitor { return NewFeed() },
+		Validator:          func(actual, expected xmlutils.Visitor) error { return nil },
+	}
+
+	if err := testcase.CheckTestCase(); err != nil {
+		t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+	}
+}
@@ -23,10 +23,10 @@ func NewGenerator() *Generator {
 	g := Generator{depth: xmlutils.NewDepthWatcher()}
 
 	g.Uri = xmlutils.NewElement("uri", "", IsValidIRI)
-	g.Uri.SetOccurence(xmlutils.NewOccurence("uri", xmlutils.UniqueValidator(AttributeDuplicated)))
+	g.Uri.SetOccurence(xmlutils.NewOccurence("uri", isUnique))
 
 	g.Version = xmlutils.NewElement("version", "", xmlutils.Nop)
-	g.Version.SetOccurence(xmlutils.NewOccurence("version", xmlutils.UniqueValidator(AttributeDuplicated)))
+	g.Version.SetOccurence(xmlutils.NewOccurence("version", isUnique))
 
 	g.InitCommonAttributes()
 
@@ -49,7 +49,9 @@ func (g *Generator) reset() {
 
 func (g *Generator) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if g.depth.IsRoot() {
+		g.Extension = extension.InitExtension(el.Path, g.Extension.Manager)
 		g.reset()
+		g.InheritLang(el)
 		for _, attr := range el.Attr {
 			switch attr.Name.Space {
 			case xmlutils.XML_NS:
@@ -37,7 +37,9 @@ func NewIconExt(manager extension.Manager) *Icon {
 
 func (i *Icon) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if i.depth.IsRoot() {
+		i.Extension = extension.InitExtension(el.Path, i.Extension.Manager)
 		i.ResetAttr()
+		i.InheritLang(el)
 		for _, attr := range el.Attr {
 			if !i.ProcessAttr(attr) {
 				i.Extension.ProcessAttr(attr, i)
@@ -38,7 +38,9 @@ func NewIdExt(manager extension.Manager) *Id {
 }
 func (i *Id) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if i.depth.IsRoot() {
+		i.Extension = extension.InitExtension(el.Path, i.Extension.Manager)
 		i.ResetAttr()
+		i.InheritLang(el)
 		for _, attr := range el.Attr {
 			if !i.ProcessAttr(attr) {
 				i.Extension.ProcessAttr(attr, i)
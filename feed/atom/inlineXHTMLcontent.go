@@ -16,6 +16,7 @@ type InlineXHTMLContent struct {
 	Encoder   *xml.Encoder
 	depth     xmlutils.DepthWatcher
 	completed bool
+	limits    *xmlutils.Limits
 	Parent    xmlutils.Visitor
 }
 
@@ -69,12 +70,16 @@ func (i *InlineXHTMLContent) ProcessStartElement(el xmlutils.StartElement) (xmlu
 	}
 
 	i.depth.Down()
+	i.limits = el.Limits
 	if error := i.CheckXHTMLSpace(el); error != nil {
 		err.NewError(error)
 	}
 	if error := i.EncodeXHTMLToken(el); error != nil {
 		err.NewError(xmlutils.NewError(XHTMLEncodeToStringError, "cannot encode XHTML"))
 	}
+	if error := i.limits.CheckContentLength(i.Content.Len()); error != nil {
+		err.NewError(error)
+	}
 
 	return i, err.ErrorObject()
 }
@@ -124,6 +129,10 @@ func (i *InlineXHTMLContent) ProcessCharData(el xml.CharData) (xmlutils.Visitor,
 			return i, xmlutils.NewError(CannotFlush, "cannot flush content")
 		}
 
+		if err := i.limits.CheckContentLength(i.Content.Len()); err != nil {
+			return i, err
+		}
+
 		if i.depth.Level == 0 {
 			return i, xmlutils.NewError(XHTMLRootNodeNotDiv, "XHTML element should have a root")
 		}
@@ -18,13 +18,14 @@ type InlineOtherContent struct {
 
 	Parent xmlutils.Visitor
 	depth  xmlutils.DepthWatcher
+	limits *xmlutils.Limits
 }
 
 func NewInlineOtherContent() *InlineOtherContent {
 	i := InlineOtherContent{hasChild: false}
 
 	i.Type = xmlutils.NewElement("type", "", IsValidMIME)
-	i.Type.SetOccurence(xmlutils.NewOccurence("type", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	i.Type.SetOccurence(xmlutils.NewOccurence("type", existsAndIsUnique))
 	i.Content = &bytes.Buffer{}
 
 	i.Encoder = xml.NewEncoder(i.Content)
@@ -33,6 +34,7 @@ func NewInlineOtherContent() *InlineOtherContent {
 
 func (i *InlineOtherContent) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	err := utils.NewErrorAggregator()
+	i.limits = el.Limits
 
 	if i.depth.IsRoot() {
 		for _, attr := range el.Attr {
@@ -55,7 +57,7 @@ func (i *InlineOtherContent) ProcessStartElement(el xmlutils.StartElement) (xmlu
 
 	i.depth.Down()
 
-	return i, nil
+	return i, i.limits.CheckContentLength(i.Content.Len())
 }
 
 func (i *InlineOtherContent) ProcessEndElement(el xml.EndElement) (xmlutils.Visitor, xmlutils.ParserError) {
@@ -76,6 +78,11 @@ func (i *InlineOtherContent) ProcessEndElement(el xml.EndElement) (xmlutils.Visi
 func (i *InlineOtherContent) ProcessCharData(el xml.CharData) (xmlutils.Visitor, xmlutils.ParserError) {
 	if len(strings.Fields(string(el))) > 0 {
 		i.Encoder.EncodeToken(el)
+		i.Encoder.Flush()
+
+		if err := i.limits.CheckContentLength(i.Content.Len()); err != nil {
+			return i, err
+		}
 	}
 	return i, nil
 }
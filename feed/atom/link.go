@@ -26,22 +26,22 @@ func NewLink() *Link {
 	l := Link{depth: xmlutils.NewDepthWatcher()}
 
 	l.Href = xmlutils.NewElement("href", "", IsValidIRI)
-	l.Href.SetOccurence(xmlutils.NewOccurence("href", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	l.Href.SetOccurence(xmlutils.NewOccurence("href", existsAndIsUnique))
 
-	l.Rel = xmlutils.NewElement("rel", "alternate", xmlutils.Nop)
-	l.Rel.SetOccurence(xmlutils.NewOccurence("rel", xmlutils.UniqueValidator(AttributeDuplicated)))
+	l.Rel = xmlutils.NewElement("rel", "alternate", relIsValid)
+	l.Rel.SetOccurence(xmlutils.NewOccurence("rel", isUnique))
 
 	l.Type = xmlutils.NewElement("type", "", IsValidMIME)
-	l.Type.SetOccurence(xmlutils.NewOccurence("type", xmlutils.UniqueValidator(AttributeDuplicated)))
+	l.Type.SetOccurence(xmlutils.NewOccurence("type", isUnique))
 
 	l.HrefLang = xmlutils.NewElement("hreflang", "", xmlutils.Nop)
-	l.HrefLang.SetOccurence(xmlutils.NewOccurence("hreflang", xmlutils.UniqueValidator(AttributeDuplicated)))
+	l.HrefLang.SetOccurence(xmlutils.NewOccurence("hreflang", isUnique))
 
 	l.Title = xmlutils.NewElement("title", "", xmlutils.Nop)
-	l.Title.SetOccurence(xmlutils.NewOccurence("title", xmlutils.UniqueValidator(AttributeDuplicated)))
+	l.Title.SetOccurence(xmlutils.NewOccurence("title", isUnique))
 
 	l.Length = xmlutils.NewElement("length", "", IsValidLength)
-	l.Length.SetOccurence(xmlutils.NewOccurence("length", xmlutils.UniqueValidator(AttributeDuplicated)))
+	l.Length.SetOccurence(xmlutils.NewOccurence("length", isUnique))
 
 	l.InitCommonAttributes()
 
@@ -71,7 +71,9 @@ func (l *Link) reset() {
 
 func (l *Link) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if l.depth.IsRoot() {
+		l.Extension = extension.InitExtension(el.Path, l.Extension.Manager)
 		l.reset()
+		l.InheritLang(el)
 		for _, attr := range el.Attr {
 			switch attr.Name.Space {
 			case xmlutils.XML_NS:
@@ -107,6 +109,12 @@ func (l *Link) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor,
 				l.Extension.ProcessAttr(attr, l)
 			}
 		}
+	} else {
+		switch el.Name.Space {
+		case "", "http://www.w3.org/2005/atom":
+		default:
+			return l.Extension.ProcessElement(el, l)
+		}
 	}
 
 	l.depth.Down()
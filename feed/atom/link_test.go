@@ -2,6 +2,7 @@ package atom
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	xmlutils "github.com/jloup/xml/utils"
@@ -110,6 +111,22 @@ func TestLinkBasic(t *testing.T) {
 			xmlutils.NewError(NotPositiveNumber, ""),
 			NewTestLink("http://www.go.com", "alternate", "text/html", "", "", "ab"),
 		},
+		{`<link rel="hub" href="http://pubsubhubbub.appspot.com/"/>`,
+			nil,
+			NewTestLink("http://pubsubhubbub.appspot.com/", "hub", "", "", "", ""),
+		},
+		{`<link rel="http://example.org/rel/podcast" href="http://www.go.com"/>`,
+			nil,
+			NewTestLink("http://www.go.com", "http://example.org/rel/podcast", "", "", "", ""),
+		},
+		{`<link rel="attachment wp-att-1" href="http://www.go.com"/>`,
+			xmlutils.NewError(RelNotValid, ""),
+			NewTestLink("http://www.go.com", "attachment wp-att-1", "", "", "", ""),
+		},
+		{`<link rel="unknown" href="http://www.go.com"/>`,
+			xmlutils.NewError(RelNotValid, ""),
+			NewTestLink("http://www.go.com", "unknown", "", "", "", ""),
+		},
 	}
 	nbErrors := 0
 	len := len(testdata)
@@ -124,3 +141,91 @@ func TestLinkBasic(t *testing.T) {
 
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestNormalizeRel(t *testing.T) {
+	var testdata = []struct {
+		Rel      string
+		Expected string
+		Valid    bool
+	}{
+		{"self", "self", true},
+		{"Next", "next", true},
+		{"http://www.iana.org/assignments/relation/replies", "replies", true},
+		{"http://example.org/rel", "http://example.org/rel", true},
+		{"wp-att", "wp-att", false},
+		{"", "", false},
+	}
+
+	for _, test := range testdata {
+		if rel := NormalizeRel(test.Rel); rel != test.Expected || IsValidRel(test.Rel) != test.Valid {
+			t.Errorf("%s: expected %s (valid %v), got %s (valid %v)", test.Rel, test.Expected, test.Valid, rel, IsValidRel(test.Rel))
+		}
+	}
+}
+
+func TestFeedLinks(t *testing.T) {
+	f := NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+  <feed xmlns="http://www.w3.org/2005/Atom">
+    <title>feed title</title>
+    <id>tag:example.org,2003:3</id>
+    <updated>2005-07-31T12:29:29Z</updated>
+    <author><name>Feed Author</name></author>
+    <link href="http://example.org/"/>
+    <link rel="alternate" hreflang="fr-FR" type="text/html; charset=utf-8" href="http://example.org/fr/"/>
+    <link rel="http://www.iana.org/assignments/relation/self" href="http://example.org/feed.atom?page=2"/>
+    <link rel="hub" href="http://hub.example.org/"/>
+    <link rel="next" href="http://example.org/feed.atom?page=3"/>
+    <link rel="prev" href="http://example.org/feed.atom?page=1"/>
+    <entry>
+      <title>entry title</title>
+      <id>tag:example.org,2003:3.2397</id>
+      <updated>2005-07-31T12:29:29Z</updated>
+      <summary>entry summary</summary>
+      <link href="http://example.org/2005/07/31"/>
+      <link rel="edit" href="http://example.org/edit/2397"/>
+      <link rel="enclosure" type="audio/mpeg" href="http://example.org/2397.mp3"/>
+    </entry>
+  </feed>`), f, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	href := func(l *Link) string {
+		if l == nil {
+			return ""
+		}
+		return l.Href.String()
+	}
+
+	entry := f.Entries[0]
+	var testdata = []struct {
+		Name     string
+		Actual   *Link
+		Expected string
+	}{
+		{"self", f.Self(), "http://example.org/feed.atom?page=2"},
+		{"alternate", f.Alternate("", ""), "http://example.org/"},
+		{"alternate fr", f.Alternate("fr", "text/html"), "http://example.org/fr/"},
+		{"alternate de", f.Alternate("de", ""), ""},
+		{"hub", f.Hub(), "http://hub.example.org/"},
+		{"next", f.Next(), "http://example.org/feed.atom?page=3"},
+		{"previous", f.Previous(), "http://example.org/feed.atom?page=1"},
+		{"last", f.Last(), ""},
+		{"entry edit", entry.Edit(), "http://example.org/edit/2397"},
+		{"entry alternate", entry.Alternate("", "text/html"), ""},
+	}
+
+	for _, test := range testdata {
+		if href(test.Actual) != test.Expected {
+			t.Errorf("%s: expected '%s', got '%s'", test.Name, test.Expected, href(test.Actual))
+		}
+	}
+
+	if len(f.LinksByRel("alternate")) != 2 || len(entry.Enclosures()) != 1 {
+		t.Errorf("unexpected links %d alternate, %d enclosures", len(f.LinksByRel("alternate")), len(entry.Enclosures()))
+	}
+}
@@ -37,7 +37,9 @@ func NewLogoExt(manager extension.Manager) *Logo {
 
 func (l *Logo) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if l.depth.IsRoot() {
+		l.Extension = extension.InitExtension(el.Path, l.Extension.Manager)
 		l.ResetAttr()
+		l.InheritLang(el)
 		for _, attr := range el.Attr {
 			if !l.ProcessAttr(attr) {
 				l.Extension.ProcessAttr(attr, l)
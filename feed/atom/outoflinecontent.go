@@ -19,10 +19,10 @@ func NewOutOfLineContent() *OutOfLineContent {
 	o := OutOfLineContent{depth: xmlutils.NewDepthWatcher()}
 
 	o.Type = xmlutils.NewElement("type", "", outOfLineTypeIsValid)
-	o.Type.SetOccurence(xmlutils.NewOccurence("type", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	o.Type.SetOccurence(xmlutils.NewOccurence("type", existsAndIsUnique))
 
 	o.Src = xmlutils.NewElement("src", "", IsValidIRI)
-	o.Src.SetOccurence(xmlutils.NewOccurence("src", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	o.Src.SetOccurence(xmlutils.NewOccurence("src", existsAndIsUnique))
 
 	o.depth.SetMaxDepth(1)
 	return &o
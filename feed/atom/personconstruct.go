@@ -49,13 +49,13 @@ func NewPersonExt(manager extension.Manager) *Person {
 func (p *Person) init() {
 
 	p.Name.Content = xmlutils.NewElement("name", "", xmlutils.Nop)
-	p.Name.Content.SetOccurence(xmlutils.NewOccurence("name", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	p.Name.Content.SetOccurence(xmlutils.NewOccurence("name", existsAndIsUnique))
 
 	p.Uri.Content = xmlutils.NewElement("uri", "", IsValidIRI)
-	p.Uri.Content.SetOccurence(xmlutils.NewOccurence("uri", xmlutils.UniqueValidator(AttributeDuplicated)))
+	p.Uri.Content.SetOccurence(xmlutils.NewOccurence("uri", isUnique))
 
 	p.Email.Content = xmlutils.NewElement("email", "", xmlutils.Nop)
-	p.Email.Content.SetOccurence(xmlutils.NewOccurence("email", xmlutils.UniqueValidator(AttributeDuplicated)))
+	p.Email.Content.SetOccurence(xmlutils.NewOccurence("email", isUnique))
 
 	p.InitCommonAttributes()
 
@@ -72,7 +72,8 @@ func (p *Person) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor
 	if p.depth.IsRoot() {
 		p.reset()
 		p.name = el.Name.Local
-		p.Extension = extension.InitExtension(p.name, p.Extension.Manager)
+		p.Extension = extension.InitExtension(el.Path, p.Extension.Manager, "person")
+		p.InheritLang(el)
 		for _, attr := range el.Attr {
 			if !p.ProcessAttr(attr) {
 				p.Extension.ProcessAttr(attr, p)
@@ -0,0 +1,145 @@
+package atom
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// IANARelPrefix is the IRI prefix which makes a registered relation an
+// absolute IRI: "http://www.iana.org/assignments/relation/self" is "self"
+const IANARelPrefix = "http://www.iana.org/assignments/relation/"
+
+// ianaRels are the relations of the IANA Link Relations registry
+var ianaRels = []string{
+	"about", "acl", "alternate", "amphtml", "api-catalog", "appendix",
+	"apple-touch-icon", "apple-touch-startup-image", "archives", "author",
+	"blocked-by", "bookmark", "canonical", "chapter", "cite-as", "collection",
+	"contents", "convertedfrom", "copyright", "create-form", "current",
+	"describedby", "describes", "disclosure", "dns-prefetch", "duplicate",
+	"edit", "edit-form", "edit-media", "enclosure", "external", "first",
+	"glossary", "help", "hosts", "hub", "icon", "index", "intervalafter",
+	"intervalbefore", "intervalcontains", "intervaldisjoint", "intervalduring",
+	"intervalequals", "intervalfinishedby", "intervalfinishes", "intervalin",
+	"intervalmeets", "intervalmetby", "intervaloverlappedby", "intervaloverlaps",
+	"intervalstartedby", "intervalstarts", "item", "last", "latest-version",
+	"license", "linkset", "lrdd", "manifest", "mask-icon", "me", "media-feed",
+	"memento", "micropub", "modulepreload", "monitor", "monitor-group", "next",
+	"next-archive", "nofollow", "noopener", "noreferrer", "opener",
+	"openid2.local_id", "openid2.provider", "original", "p3pv1", "payment",
+	"pingback", "preconnect", "predecessor-version", "prefetch", "preload",
+	"prerender", "prev", "prev-archive", "preview", "previous",
+	"privacy-policy", "profile", "publication", "related", "replies",
+	"restconf", "ruleinput", "search", "section", "self", "service",
+	"service-desc", "service-doc", "sitemap", "sponsored", "start", "status",
+	"stylesheet", "subsection", "successor-version", "sunset", "tag",
+	"terms-of-service", "timegate", "timemap", "type", "ugc", "up",
+	"version-history", "via", "webmention", "working-copy", "working-copy-of",
+}
+
+var relRegistry = struct {
+	sync.RWMutex
+	rels map[string]bool
+}{rels: make(map[string]bool)}
+
+func init() {
+	RegisterRel(ianaRels...)
+}
+
+// RegisterRel adds relation names to the registry, e.g. relations registered
+// after this package has been written. Relations which are absolute IRIs need
+// not be registered.
+func RegisterRel(rels ...string) {
+	relRegistry.Lock()
+	defer relRegistry.Unlock()
+
+	for _, rel := range rels {
+		relRegistry.rels[strings.ToLower(rel)] = true
+	}
+}
+
+func isRegisteredRel(name string) bool {
+	relRegistry.RLock()
+	defer relRegistry.RUnlock()
+
+	return relRegistry.rels[name]
+}
+
+// NormalizeRel returns the registered name of rel: IANA IRIs are shortened
+// and names lower cased, as relations are compared case-insensitively. Other
+// relations are returned as is.
+func NormalizeRel(rel string) string {
+	if name := strings.ToLower(strings.TrimPrefix(rel, IANARelPrefix)); isRegisteredRel(name) {
+		return name
+	}
+	return rel
+}
+
+// IsValidRel reports whether rel is a registered relation or an absolute IRI
+// (extension relation)
+func IsValidRel(rel string) bool {
+	if isRegisteredRel(strings.ToLower(rel)) {
+		return true
+	}
+
+	if rel == "" || strings.ContainsAny(rel, " \t\r\n") {
+		return false
+	}
+
+	u, err := url.Parse(rel)
+	return err == nil && u.IsAbs()
+}
+
+func relIsValid(name, s string) xmlutils.ParserError {
+	if IsValidRel(s) {
+		return nil
+	}
+
+	return xmlutils.NewError(RelNotValid, fmt.Sprintf("%s is neither a registered relation nor an absolute IRI: '%s'", name, s))
+}
+
+// Links are the atom:link of a feed, an entry or a source
+type Links []*Link
+
+// ByRel returns the links whose relation is rel, compared with NormalizeRel
+func (l Links) ByRel(rel string) Links {
+	rel = NormalizeRel(rel)
+
+	var links Links
+	for _, link := range l {
+		if NormalizeRel(link.Rel.Value) == rel {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// First returns the first link whose relation is rel, or nil
+func (l Links) First(rel string) *Link {
+	if links := l.ByRel(rel); len(links) > 0 {
+		return links[0]
+	}
+	return nil
+}
+
+// Alternate returns the first alternate link in language lang and of media
+// type typ, or nil. An empty lang or typ matches any link; lang "en" matches
+// hreflang "en-US".
+func (l Links) Alternate(lang, typ string) *Link {
+	for _, link := range l.ByRel("alternate") {
+		hreflang := link.HrefLang.Value
+		if lang != "" && !strings.EqualFold(hreflang, lang) && !strings.HasPrefix(strings.ToLower(hreflang), strings.ToLower(lang)+"-") {
+			continue
+		}
+
+		if typ != "" && !strings.EqualFold(strings.TrimSpace(strings.SplitN(link.Type.Value, ";", 2)[0]), typ) {
+			continue
+		}
+
+		return link
+	}
+	return nil
+}
@@ -17,7 +17,7 @@ type Source struct {
 	Generator    *Generator
 	Icon         *Icon
 	Id           *Id
-	Links        []*Link
+	Links        Links
 	Logo         *Logo
 	Rights       *TextConstruct
 	Subtitle     *TextConstruct
@@ -80,14 +80,14 @@ func (s *Source) init() {
 	s.Updated.Parent = s
 
 	s.Occurences = xmlutils.NewOccurenceCollection(
-		xmlutils.NewOccurence("generator", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("icon", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("logo", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("id", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)),
-		xmlutils.NewOccurence("rights", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("subtitle", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("title", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)),
-		xmlutils.NewOccurence("updated", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)),
+		xmlutils.NewOccurence("generator", isUnique),
+		xmlutils.NewOccurence("icon", isUnique),
+		xmlutils.NewOccurence("logo", isUnique),
+		xmlutils.NewOccurence("id", existsAndIsUnique),
+		xmlutils.NewOccurence("rights", isUnique),
+		xmlutils.NewOccurence("subtitle", isUnique),
+		xmlutils.NewOccurence("title", existsAndIsUnique),
+		xmlutils.NewOccurence("updated", existsAndIsUnique),
 	)
 
 	s.InitCommonAttributes()
@@ -100,7 +100,9 @@ func (s *Source) reset() {
 
 func (s *Source) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if s.depth.IsRoot() {
+		s.Extension = extension.InitExtension(el.Path, s.Extension.Manager)
 		s.reset()
+		s.InheritLang(el)
 		for _, attr := range el.Attr {
 			if !s.ProcessAttr(attr) {
 				s.Extension.ProcessAttr(attr, s)
@@ -212,7 +214,7 @@ func (s *Source) validateLinks(err *utils.ErrorAggregator) {
 	combinations := make([]string, 0)
 
 	for _, link := range s.Links {
-		if link.Rel.Value == "alternate" {
+		if NormalizeRel(link.Rel.Value) == "alternate" {
 			s := link.Type.Value + link.HrefLang.Value
 			unique := true
 
@@ -230,3 +232,19 @@ func (s *Source) validateLinks(err *utils.ErrorAggregator) {
 	}
 
 }
+
+// LinksByRel returns the links of s whose relation is rel, see NormalizeRel
+func (s *Source) LinksByRel(rel string) Links {
+	return s.Links.ByRel(rel)
+}
+
+// Self returns the link to the feed s describes, or nil
+func (s *Source) Self() *Link {
+	return s.Links.First("self")
+}
+
+// Alternate returns the first alternate link of s in language lang and of
+// media type typ, see Links.Alternate
+func (s *Source) Alternate(lang, typ string) *Link {
+	return s.Links.Alternate(lang, typ)
+}
@@ -43,9 +43,10 @@ func (t *TextConstruct) reset() {
 
 func (t *TextConstruct) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	t.name = el.Name.Local
-	t.Extension = extension.InitExtension(t.name, t.Extension.Manager)
+	t.Extension = extension.InitExtension(el.Path, t.Extension.Manager, "textconstruct")
 	t.reset()
 
+	t.InheritLang(el)
 	for _, attr := range el.Attr {
 		switch attr.Name.Space {
 		case xmlutils.XML_NS:
@@ -82,6 +83,7 @@ func (t *TextConstruct) Validate() xmlutils.ParserError {
 	err := utils.NewErrorAggregator()
 
 	t.ValidateCommonAttributes(t.name, &err)
+	t.Extension.Validate(&err)
 
 	return err.ErrorObject()
 }
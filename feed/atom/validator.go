@@ -1,8 +1,6 @@
 package atom
 
 import (
-	"fmt"
-
 	xmlutils "github.com/jloup/xml/utils"
 )
 
@@ -12,15 +10,12 @@ var (
 	IsValidLength  = xmlutils.IsValidNumber(NotPositiveNumber)
 	IsValidMIME    = xmlutils.IsValidMIME(IsNotMIME)
 	IsXMLMediaType = xmlutils.IsValidXMLMediaType(NotXMLMediaType)
-)
-
-func relIsIANA(name, s string) xmlutils.ParserError {
-	if s == "alternate" || s == "related" || s == "self" || s == "enclosure" || s == "via" {
-		return nil
-	}
 
-	return xmlutils.NewError(RelNotValid, fmt.Sprintf("rel is not valid: %s", s))
-}
+	// occurence validators only read the occurence they check: elements share
+	// them rather than allocate their own
+	isUnique          = xmlutils.UniqueValidator(AttributeDuplicated)
+	existsAndIsUnique = xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)
+)
 
 func contentTypeIsValid(name, s string) xmlutils.ParserError {
 	if s == "text" || s == "html" || s == "xhtml" {
@@ -1,27 +1,115 @@
 package feed
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/category"
+	"github.com/jloup/xml/feed/person"
 	"github.com/jloup/xml/feed/rss"
 )
 
-// BasicEntryBlock is a common brick to build UserFeed
+// BasicEntryBlock is a common brick to build UserFeed. The comment of each
+// field tells where it comes from; when several sources are listed, the first
+// one set is used.
 type BasicEntryBlock struct {
-	Title   string
-	Link    string
-	Date    time.Time
-	Id      string
+	Title string
+	// Atom:entry:link (first alternate) | RSS:item:link
+	Link string
+	// Atom:entry:updated, Atom:entry:published | RSS:item:pubDate
+	Date time.Time
+	// Atom:entry:published | RSS:item:pubDate
+	Published time.Time
+	// Atom:entry:id | RSS:item:guid
+	Id string
+	// Atom:entry:summary | RSS:item:description
 	Summary string
+	// Atom:entry:content | RSS:item:description
+	Content string
+	// Atom:entry:xml:lang (inherited) | RSS:channel:language, xml:lang
+	Language string
+	// Atom:entry:link (all, in document order) | RSS:item:link (alternate),
+	// RSS:item:enclosure (enclosure)
+	Links      BasicLinks
+	Enclosures []BasicEnclosure
+	// Authors are inherited from the source then the feed when the entry has
+	// none, see package person
+	Authors []person.Person
+	// Categories are normalized without splitting, see package category
+	Categories []category.Category
+	// Atom:entry:link (first replies) | RSS:item:comments
+	Comments string
+	// Atom:entry:source | RSS:item:source, zero when the entry has not been
+	// copied from another feed
+	Source BasicSource
+}
+
+// BasicEnclosure is a file attached to an entry: a RSS enclosure or an Atom
+// link with the enclosure relation. Length is 0 when unknown.
+type BasicEnclosure struct {
+	Url    string
+	Type   string
+	Length int64
+}
+
+// BasicLink is a link of a feed or an entry. Rel is "alternate" when the
+// document does not set it.
+type BasicLink struct {
+	Rel   string
+	Href  string
+	Type  string
+	Title string
+}
+
+// BasicLinks are links in document order
+type BasicLinks []BasicLink
+
+// ByRel returns the links whose relation is rel
+func (l BasicLinks) ByRel(rel string) BasicLinks {
+	var links BasicLinks
+	for _, link := range l {
+		if link.Rel == rel {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// First returns the href of the first link whose relation is rel, or ""
+func (l BasicLinks) First(rel string) string {
+	for _, link := range l {
+		if link.Rel == rel {
+			return link.Href
+		}
+	}
+	return ""
 }
 
-// BasicFeedBlock is a common brick to build UserFeed
+// BasicFeedBlock is a common brick to build UserFeed. Fields follow the same
+// rules as BasicEntryBlock.
 type BasicFeedBlock struct {
 	Title string
-	Id    string
-	Date  time.Time
+	// Atom:feed:id | RSS:channel:link
+	Id string
+	// Atom:feed:subtitle | RSS:channel:description
+	Description string
+	// Atom:feed:xml:lang (inherited) | RSS:channel:language, xml:lang
+	Language string
+	// Atom:feed:link (first alternate) | RSS:channel:link
+	Link string
+	// Atom:feed:link (all, in document order) | RSS:channel:link (alternate)
+	Links BasicLinks
+	// Atom:feed:updated | RSS:channel:lastBuildDate, RSS:channel:pubDate
+	Date time.Time
+	// RSS:channel:pubDate
+	Published time.Time
+	// Atom:feed:logo | RSS:channel:image:url
 	Image string
+	// Atom:feed:icon
+	Icon string
+	// Atom:feed:author | RSS:channel:managingEditor
+	Authors []person.Person
 }
 
 // BasicFeed is a basic UserFeed
@@ -44,31 +132,74 @@ func (b *BasicFeed) PopulateFromAtomEntry(e *atom.Entry) {
 	b.Entries = append(b.Entries, newEntry)
 }
 
+func atomLinks(links []*atom.Link) BasicLinks {
+	var l BasicLinks
+	for _, link := range links {
+		l = append(l, BasicLink{Rel: link.Rel.String(), Href: link.Href.String(), Type: link.Type.String(), Title: link.Title.String()})
+	}
+	return l
+}
+
 func (b *BasicFeedBlock) PopulateFromAtomFeed(f *atom.Feed) {
 	b.Title = f.Title.String()
 	b.Date = f.Updated.Time
 	b.Id = f.Id.String()
 	b.Image = f.Logo.Iri.String()
+	b.Icon = f.Icon.Iri.String()
+	b.Description = f.Subtitle.String()
+	b.Language = f.EffectiveLang()
+	b.Links = atomLinks(f.Links)
+	b.Link = b.Links.First("alternate")
+	b.Authors = person.FromAtomPersons(f.Authors)
 }
 
 func (b *BasicEntryBlock) PopulateFromAtomEntry(e *atom.Entry) {
 	b.Title = e.Title.String()
 	b.Id = e.Id.String()
 	b.Date = e.Updated.Time
+	b.Published = e.Published.Time
 	b.Summary = e.Summary.String()
+	b.Content = e.Content.String()
+	b.Language = e.EffectiveLang()
+	b.Authors = person.AtomEntryAuthors(e)
+	b.Categories = category.FromAtomEntry(e)
+
+	if b.Date.IsZero() {
+		b.Date = b.Published
+	}
+
+	b.Links = atomLinks(e.Links)
+	b.Link = b.Links.First("alternate")
+	b.Comments = b.Links.First("replies")
 
 	for _, link := range e.Links {
-		if link.Rel.String() == "alternate" {
-			b.Link = link.Href.String()
+		if link.Rel.String() == "enclosure" {
+			length, _ := strconv.ParseInt(link.Length.String(), 10, 64)
+			b.Enclosures = append(b.Enclosures, BasicEnclosure{Url: link.Href.String(), Type: link.Type.String(), Length: length})
 		}
 	}
+
+	b.Source = SourceFromAtomSource(e.Source)
 }
 
 func (b *BasicFeedBlock) PopulateFromRssChannel(c *rss.Channel) {
 	b.Title = c.Title.String()
 	b.Date = c.LastBuildDate.Time
+	b.Published = c.PubDate.Time
 	b.Id = c.Link.String()
+	b.Link = c.Link.String()
 	b.Image = c.Image.Url.String()
+	b.Description = c.Description.String()
+	b.Language = c.EffectiveLang()
+	b.Authors = person.RssChannelAuthors(c)
+
+	if b.Date.IsZero() {
+		b.Date = b.Published
+	}
+
+	if b.Link != "" {
+		b.Links = BasicLinks{{Rel: "alternate", Href: b.Link}}
+	}
 }
 
 func (b *BasicEntryBlock) PopulateFromRssItem(item *rss.Item) {
@@ -76,5 +207,26 @@ func (b *BasicEntryBlock) PopulateFromRssItem(item *rss.Item) {
 	b.Link = item.Link.String()
 	b.Id = item.Guid.Content.String()
 	b.Date = item.PubDate.Time
+	b.Published = item.PubDate.Time
 	b.Summary = item.Description.String()
+	b.Content = item.Description.String()
+	b.Comments = item.Comments.String()
+	b.Language = item.EffectiveLang()
+	b.Authors = person.RssItemAuthors(item)
+	b.Categories = category.FromRssItem(item)
+	b.Source = BasicSource{Title: item.Source.Content.String(), Self: item.Source.Url.String()}
+
+	if b.Link != "" {
+		b.Links = append(b.Links, BasicLink{Rel: "alternate", Href: b.Link})
+	}
+
+	for _, enclosure := range item.Enclosures {
+		if enclosure.Url.String() == "" {
+			continue
+		}
+
+		length, _ := enclosure.LengthBytes()
+		b.Enclosures = append(b.Enclosures, BasicEnclosure{Url: enclosure.Url.String(), Type: enclosure.Type.String(), Length: length})
+		b.Links = append(b.Links, BasicLink{Rel: "enclosure", Href: enclosure.Url.String(), Type: enclosure.Type.String()})
+	}
 }
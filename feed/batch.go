@@ -0,0 +1,145 @@
+package feed
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// Document is an input of ParseBatch and ParseStream
+type Document struct {
+	// Name identifies the document in its Result, e.g. the URL it was fetched from
+	Name   string
+	Reader io.Reader
+	// ContentType of the document, e.g. from HTTP headers. When set, it
+	// overrides BatchOptions.ContentType.
+	ContentType string
+}
+
+// Result is the outcome of parsing a Document
+type Result struct {
+	// Index is the position of the document in the batch, or in the stream
+	Index int
+	Name  string
+	Feed  UserFeed
+	Err   error
+	// Encoding tells how the encoding of the document has been detected
+	Encoding xmlutils.EncodingReport
+	// Repairs made to the document, when BatchOptions.Recover is set
+	Repairs []xmlutils.Repair
+}
+
+// BatchOptions is passed to ParseBatch and ParseStream. The embedded
+// ParseOptions are shared by every worker: the extension Manager and the
+// ErrorFlags checker are only read while parsing, so they must not be modified
+// until the batch is done.
+type BatchOptions struct {
+	ParseOptions
+	// Workers is the maximum number of documents parsed at the same time.
+	// Defaults to runtime.GOMAXPROCS(0).
+	Workers int
+	// NewFeed builds the UserFeed populated from each document. Defaults to
+	// returning a *BasicFeed.
+	NewFeed func() UserFeed
+	// Recover enables the repair of malformed documents before parsing them
+	// (see ParseCustomRecover)
+	Recover bool
+}
+
+func (o *BatchOptions) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (o *BatchOptions) newFeed() UserFeed {
+	if o.NewFeed != nil {
+		return o.NewFeed()
+	}
+	return &BasicFeed{}
+}
+
+// ParseStream parses the documents received from docs with at most
+// options.Workers goroutines, and sends one Result per document on the
+// returned channel, in completion order. The channel is closed once docs is
+// closed and every document has been parsed; it must be drained.
+//
+// When ctx is done, documents which have not started yet are reported with
+// ctx.Err() without being parsed, and documents being parsed are aborted with a
+// xmlutils.Canceled error.
+func ParseStream(ctx context.Context, docs <-chan Document, options BatchOptions) <-chan Result {
+	type job struct {
+		index int
+		doc   Document
+	}
+
+	jobs := make(chan job)
+	results := make(chan Result)
+
+	go func() {
+		defer close(jobs)
+
+		index := 0
+		for doc := range docs {
+			jobs <- job{index, doc}
+			index++
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < options.workers(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := range jobs {
+				result := Result{Index: j.index, Name: j.doc.Name}
+
+				if err := ctx.Err(); err != nil {
+					result.Err = err
+				} else {
+					result.Feed = options.newFeed()
+					parseOptions := options.ParseOptions
+					if j.doc.ContentType != "" {
+						parseOptions.ContentType = j.doc.ContentType
+					}
+
+					result.Encoding, result.Repairs, result.Err = parseDocument(ctx, j.doc.Reader, result.Feed, parseOptions, options.Recover)
+				}
+
+				results <- result
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// ParseBatch parses docs with at most options.Workers goroutines. Results are
+// returned in the order of docs; parsing errors are reported per document in
+// Result.Err. See ParseStream for cancellation.
+func ParseBatch(ctx context.Context, docs []Document, options BatchOptions) []Result {
+	in := make(chan Document)
+	go func() {
+		defer close(in)
+		for _, doc := range docs {
+			in <- doc
+		}
+	}()
+
+	results := make([]Result, len(docs))
+	for result := range ParseStream(ctx, in, options) {
+		results[result.Index] = result
+	}
+
+	return results
+}
@@ -0,0 +1,137 @@
+package feed_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss/extension/dc"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func batchOptions() feed.BatchOptions {
+	manager := extension.Manager{}
+	dc.AddToManager(&manager)
+
+	errorFlags := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	return feed.BatchOptions{
+		ParseOptions: feed.ParseOptions{ExtensionManager: manager, ErrorFlags: &errorFlags},
+		Workers:      8,
+		NewFeed:      func() feed.UserFeed { return &ExtendedFeed{} },
+	}
+}
+
+func readTestdata(t testing.TB, names ...string) map[string][]byte {
+	files := make(map[string][]byte)
+	for _, name := range names {
+		b, err := ioutil.ReadFile("testdata/" + name)
+		if err != nil {
+			t.Fatalf("cannot read %s: %s", name, err)
+		}
+		files[name] = b
+	}
+
+	return files
+}
+
+func TestParseBatch(t *testing.T) {
+	options := batchOptions()
+	names := []string{"rss.xml", "atom.xml", "invalid_atom.xml"}
+	files := readTestdata(t, names...)
+
+	type expected struct {
+		feed feed.UserFeed
+		err  string
+	}
+
+	// sequential parses are the reference
+	want := make(map[string]expected)
+	for _, name := range names {
+		f := options.NewFeed()
+		err := feed.ParseCustom(bytes.NewReader(files[name]), f, options.ParseOptions)
+		want[name] = expected{f, fmt.Sprint(err)}
+	}
+
+	var docs []feed.Document
+	for i := 0; i < 200; i++ {
+		name := names[i%len(names)]
+		docs = append(docs, feed.Document{Name: name, Reader: bytes.NewReader(files[name])})
+	}
+
+	results := feed.ParseBatch(context.Background(), docs, options)
+
+	if len(results) != len(docs) {
+		t.Fatalf("expected %d results, got %d", len(docs), len(results))
+	}
+
+	for i, result := range results {
+		if result.Index != i || result.Name != docs[i].Name {
+			t.Errorf("result #%d: got index %d for %s", i, result.Index, result.Name)
+			continue
+		}
+
+		w := want[result.Name]
+		if err := fmt.Sprint(result.Err); err != w.err {
+			t.Errorf("result #%d (%s): expected error '%s', got '%s'", i, result.Name, w.err, err)
+		}
+
+		if result.Err == nil && !reflect.DeepEqual(result.Feed, w.feed) {
+			t.Errorf("result #%d (%s): feed differs from sequential parse", i, result.Name)
+		}
+	}
+}
+
+func TestParseStreamCanceled(t *testing.T) {
+	options := batchOptions()
+	files := readTestdata(t, "rss.xml")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	docs := make(chan feed.Document)
+	go func() {
+		defer close(docs)
+		for i := 0; i < 10; i++ {
+			docs <- feed.Document{Name: "rss.xml", Reader: bytes.NewReader(files["rss.xml"])}
+		}
+	}()
+
+	seen := make(map[int]bool)
+	for result := range feed.ParseStream(ctx, docs, options) {
+		if result.Err != context.Canceled {
+			t.Errorf("result #%d: expected %s, got %v", result.Index, context.Canceled, result.Err)
+		}
+		if result.Feed != nil {
+			t.Errorf("result #%d: canceled document should not be parsed", result.Index)
+		}
+		seen[result.Index] = true
+	}
+
+	if len(seen) != 10 {
+		t.Errorf("expected 10 results, got %d", len(seen))
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	options := batchOptions().ParseOptions
+	files := readTestdata(b, "rss.xml", "atom.xml", "invalid_atom.xml")
+
+	for _, name := range []string{"rss.xml", "atom.xml", "invalid_atom.xml"} {
+		doc := files[name]
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(doc)))
+
+			for i := 0; i < b.N; i++ {
+				feed.ParseCustom(bytes.NewReader(doc), &ExtendedFeed{}, options)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package category normalizes the categories of feed entries across formats.
+//
+// Atom categories have a term, a scheme and a label; the domain of RSS
+// categories is kept as Scheme. The categories held by extensions, e.g.
+// dc:subject (package dc) or media:keywords (packages media and youtube), are
+// added without scheme when those extensions are registered in the extension
+// Manager (see extension.Categorizer).
+//
+// Some feeds put several tags in a single category, e.g. "go, xml": a Splitter
+// splits them. Terms are kept as is by default.
+package category
+
+import (
+	"strings"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/rss"
+)
+
+// Category is a category of an entry normalized from either format
+type Category struct {
+	Term string
+	// Scheme is the Atom scheme or the RSS domain
+	Scheme string
+	// Label is the Atom label, empty for RSS
+	Label string
+}
+
+// Key identifies c regardless of case and white spaces, e.g. for tag clouds.
+// Categories with different schemes have different keys.
+func (c Category) Key() string {
+	return strings.TrimSpace(c.Scheme) + " " + normalizeText(c.Term)
+}
+
+// Display returns Label, or Term when c has no label
+func (c Category) Display() string {
+	if c.Label != "" {
+		return c.Label
+	}
+	return c.Term
+}
+
+// normalizeText collapses white spaces and ignores case
+func normalizeText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// Splitter splits the term of c into several terms
+type Splitter func(c Category) []string
+
+// SplitOn returns a Splitter splitting terms on any character of separators,
+// e.g. SplitOn(",;")
+func SplitOn(separators string) Splitter {
+	return func(c Category) []string {
+		return strings.FieldsFunc(c.Term, func(r rune) bool { return strings.ContainsRune(separators, r) })
+	}
+}
+
+// SplitSchemes returns a Splitter using split for categories whose scheme is
+// one of schemes only, e.g. to split the categories of a single RSS domain
+func SplitSchemes(split Splitter, schemes ...string) Splitter {
+	return func(c Category) []string {
+		for _, scheme := range schemes {
+			if c.Scheme == scheme {
+				return split(c)
+			}
+		}
+		return []string{c.Term}
+	}
+}
+
+// Normalizer converts the categories of entries
+type Normalizer struct {
+	// Split splits terms holding several categories; nil keeps them as is
+	Split Splitter
+}
+
+// DefaultNormalizer does not split terms
+var DefaultNormalizer = Normalizer{}
+
+// FromAtomEntry is a shortcut to DefaultNormalizer.FromAtomEntry
+func FromAtomEntry(e *atom.Entry) []Category {
+	return DefaultNormalizer.FromAtomEntry(e)
+}
+
+// FromRssItem is a shortcut to DefaultNormalizer.FromRssItem
+func FromRssItem(i *rss.Item) []Category {
+	return DefaultNormalizer.FromRssItem(i)
+}
+
+// FromAtomEntry uses the categories of e, then the ones of its extensions
+func (n Normalizer) FromAtomEntry(e *atom.Entry) []Category {
+	var categories []Category
+	for _, c := range e.Categories {
+		categories = append(categories, Category{Term: c.Term.String(), Scheme: c.Scheme.String(), Label: c.Label.String()})
+	}
+
+	for _, term := range e.Extension.Store.Categories() {
+		categories = append(categories, Category{Term: term})
+	}
+
+	return n.Normalize(categories...)
+}
+
+// FromRssItem uses the categories of i, then the ones of its extensions
+func (n Normalizer) FromRssItem(i *rss.Item) []Category {
+	var categories []Category
+	for _, c := range i.Categories {
+		categories = append(categories, Category{Term: c.Content.String(), Scheme: c.Domain.String()})
+	}
+
+	for _, term := range i.Extension.Store.Categories() {
+		categories = append(categories, Category{Term: term})
+	}
+
+	return n.Normalize(categories...)
+}
+
+// Normalize splits categories, trims their terms and removes the empty ones
+// and the duplicates (same Key). The first occurrence is kept, with the label
+// of a following one when it has none. Order is kept.
+func (n Normalizer) Normalize(categories ...Category) []Category {
+	var normalized []Category
+	seen := make(map[string]int)
+
+	for _, c := range categories {
+		terms := []string{c.Term}
+		if n.Split != nil {
+			terms = n.Split(c)
+		}
+
+		for _, term := range terms {
+			split := Category{Term: strings.Join(strings.Fields(term), " "), Scheme: strings.TrimSpace(c.Scheme)}
+			if len(terms) == 1 {
+				split.Label = strings.TrimSpace(c.Label)
+			}
+
+			if split.Term == "" {
+				continue
+			}
+
+			if i, ok := seen[split.Key()]; ok {
+				if normalized[i].Label == "" {
+					normalized[i].Label = split.Label
+				}
+				continue
+			}
+
+			seen[split.Key()] = len(normalized)
+			normalized = append(normalized, split)
+		}
+	}
+
+	return normalized
+}
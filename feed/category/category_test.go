@@ -0,0 +1,101 @@
+package category
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/atom/extension/youtube"
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/dc"
+	"github.com/jloup/xml/feed/rss/extension/media"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func TestNormalize(t *testing.T) {
+	var testdata = []struct {
+		Normalizer Normalizer
+		In         []Category
+		Expected   []Category
+	}{
+		{
+			DefaultNormalizer,
+			[]Category{{Term: " Go  Lang "}, {Term: ""}, {Term: "go lang", Label: "Go"}, {Term: "go lang", Scheme: "http://example.org/"}},
+			[]Category{{Term: "Go Lang", Label: "Go"}, {Term: "go lang", Scheme: "http://example.org/"}},
+		},
+		{
+			DefaultNormalizer,
+			[]Category{{Term: "go, xml"}},
+			[]Category{{Term: "go, xml"}},
+		},
+		{
+			Normalizer{Split: SplitOn(",;")},
+			[]Category{{Term: "go, xml;; feeds", Label: "Several"}, {Term: "XML", Label: "Xml"}},
+			[]Category{{Term: "go"}, {Term: "xml", Label: "Xml"}, {Term: "feeds"}},
+		},
+		{
+			Normalizer{Split: SplitSchemes(SplitOn(","), "tags")},
+			[]Category{{Term: "go,xml", Scheme: "tags"}, {Term: "Paris, France"}},
+			[]Category{{Term: "go", Scheme: "tags"}, {Term: "xml", Scheme: "tags"}, {Term: "Paris, France"}},
+		},
+	}
+
+	for i, test := range testdata {
+		if normalized := test.Normalizer.Normalize(test.In...); !reflect.DeepEqual(normalized, test.Expected) {
+			t.Errorf("test #%d: expected %+v, got %+v", i, test.Expected, normalized)
+		}
+	}
+}
+
+func TestFromAtomEntry(t *testing.T) {
+	manager := extension.Manager{}
+	youtube.AddToManager(&manager)
+
+	e := atom.NewEntryExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	doc := `<entry xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+  <category term="go" scheme="http://example.org/tags" label="Go"/>
+  <category term="xml"/>
+  <media:group><media:keywords>XML, feeds</media:keywords></media:group>
+</entry>`
+
+	if err := xmlutils.Walk(strings.NewReader(doc), e, &checker, 0); err != nil {
+		t.Fatalf("cannot parse entry: %s", err)
+	}
+
+	expected := []Category{{Term: "go", Scheme: "http://example.org/tags", Label: "Go"}, {Term: "xml"}, {Term: "feeds"}}
+
+	if categories := FromAtomEntry(e); !reflect.DeepEqual(categories, expected) {
+		t.Errorf("expected %+v, got %+v", expected, categories)
+	}
+}
+
+func TestFromRssItem(t *testing.T) {
+	manager := extension.Manager{}
+	dc.AddToManager(&manager)
+	media.AddToManager(&manager)
+
+	i := rss.NewItemExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	doc := `<item xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:media="http://search.yahoo.com/mrss/">
+  <category domain="http://www.fool.com/cusips">MSFT</category>
+  <category>go, xml</category>
+  <dc:subject>Feeds</dc:subject>
+  <dc:subject>xml</dc:subject>
+  <media:keywords>video, feeds</media:keywords>
+</item>`
+
+	if err := xmlutils.Walk(strings.NewReader(doc), i, &checker, 0); err != nil {
+		t.Fatalf("cannot parse item: %s", err)
+	}
+
+	expected := []Category{{Term: "MSFT", Scheme: "http://www.fool.com/cusips"}, {Term: "go"}, {Term: "xml"}, {Term: "Feeds"}, {Term: "video"}}
+
+	if categories := (Normalizer{Split: SplitOn(",")}).FromRssItem(i); !reflect.DeepEqual(categories, expected) {
+		t.Errorf("expected %+v, got %+v", expected, categories)
+	}
+}
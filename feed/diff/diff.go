@@ -0,0 +1,309 @@
+// Package diff compares two parses of the same feed and reports the entries
+// which have been added, removed or modified in between.
+//
+// Entries of both snapshots are matched by their identity (see package
+// identity). The identity of an entry without guid/id nor link is computed
+// from its title or content, so a change to those fields makes it appear as
+// removed and added rather than modified.
+package diff
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/identity"
+	"github.com/jloup/xml/feed/rss"
+)
+
+// Field names an entry field compared by Entries
+type Field string
+
+const (
+	Title      Field = "title"
+	Content    Field = "content"
+	Updated    Field = "updated"
+	Links      Field = "links"
+	Enclosures Field = "enclosures"
+)
+
+// Entry is the comparable snapshot of a feed entry
+type Entry struct {
+	// Index is the position of the entry in the parsed feed
+	Index    int
+	Identity identity.Entry
+
+	Title   string
+	Content string
+	Updated time.Time
+	// Links and Enclosures are sorted so that reordering them is not a change
+	Links      []string
+	Enclosures []string
+}
+
+// FromAtomEntry snapshots e. Content falls back to summary; links with the
+// enclosure relation are reported as Enclosures.
+func FromAtomEntry(index int, e *atom.Entry) Entry {
+	entry := Entry{
+		Index:    index,
+		Identity: identity.FromAtomEntry(e),
+		Title:    e.Title.String(),
+		Content:  e.Content.String(),
+		Updated:  e.Updated.Time,
+	}
+
+	if entry.Content == "" {
+		entry.Content = e.Summary.String()
+	}
+
+	for _, link := range e.Links {
+		if link.Rel.String() == "enclosure" {
+			entry.Enclosures = append(entry.Enclosures, enclosure(link.Href.String(), link.Type.String(), link.Length.String()))
+		} else {
+			entry.Links = append(entry.Links, link.Rel.String()+" "+link.Href.String())
+		}
+	}
+
+	return entry.sorted()
+}
+
+// FromRssItem snapshots i. Updated is pubDate.
+func FromRssItem(index int, i *rss.Item) Entry {
+	entry := Entry{
+		Index:    index,
+		Identity: identity.FromRssItem(i),
+		Title:    i.Title.String(),
+		Content:  i.Description.String(),
+		Updated:  i.PubDate.Time,
+	}
+
+	if i.Link.String() != "" {
+		entry.Links = append(entry.Links, "alternate "+i.Link.String())
+	}
+
+	for _, e := range i.Enclosures {
+		if e.Url.String() != "" {
+			entry.Enclosures = append(entry.Enclosures, enclosure(e.Url.String(), e.Type.String(), e.Length.String()))
+		}
+	}
+
+	return entry.sorted()
+}
+
+// FromBasicEntry snapshots b. Content falls back to Summary, Updated is Date;
+// links with the enclosure relation are reported as Enclosures, as for
+// FromAtomEntry.
+func FromBasicEntry(index int, b feed.BasicEntryBlock) Entry {
+	entry := Entry{
+		Index:    index,
+		Identity: identity.FromBasicEntry(b),
+		Title:    b.Title,
+		Content:  b.Content,
+		Updated:  b.Date,
+	}
+
+	if entry.Content == "" {
+		entry.Content = b.Summary
+	}
+
+	for _, link := range b.Links {
+		if link.Rel != "enclosure" {
+			entry.Links = append(entry.Links, link.Rel+" "+link.Href)
+		}
+	}
+
+	for _, e := range b.Enclosures {
+		length := ""
+		if e.Length > 0 {
+			length = strconv.FormatInt(e.Length, 10)
+		}
+		entry.Enclosures = append(entry.Enclosures, enclosure(e.Url, e.Type, length))
+	}
+
+	return entry.sorted()
+}
+
+func enclosure(url, typ, length string) string {
+	return url + " " + typ + " " + length
+}
+
+func (e Entry) sorted() Entry {
+	sort.Strings(e.Links)
+	sort.Strings(e.Enclosures)
+
+	return e
+}
+
+// Change is an entry found in both snapshots with different fields
+type Change struct {
+	Old    Entry
+	New    Entry
+	Fields []Field
+}
+
+// Has reports whether field has changed
+func (c Change) Has(field Field) bool {
+	for _, f := range c.Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Result lists the differences between two snapshots, each list in the order
+// of the snapshot it comes from
+type Result struct {
+	Added    []Entry
+	Removed  []Entry
+	Modified []Change
+}
+
+// IsEmpty reports whether both snapshots hold the same entries
+func (r Result) IsEmpty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Modified) == 0
+}
+
+// Differ matches entries with Fingerprinter
+type Differ struct {
+	Fingerprinter identity.Fingerprinter
+}
+
+// DefaultDiffer uses identity.DefaultFingerprinter
+var DefaultDiffer = Differ{Fingerprinter: identity.DefaultFingerprinter}
+
+// Entries compares two snapshots. When several entries of a snapshot share an
+// identity, they are matched in order. Entries with no identity at all never
+// match.
+func (d Differ) Entries(old, new []Entry) Result {
+	result := Result{}
+
+	pending := make(map[string][]int)
+	for i, entry := range old {
+		if key, ok := d.key(entry); ok {
+			pending[key] = append(pending[key], i)
+		}
+	}
+
+	matched := make([]bool, len(old))
+	for _, entry := range new {
+		key, ok := d.key(entry)
+		if !ok || len(pending[key]) == 0 {
+			result.Added = append(result.Added, entry)
+			continue
+		}
+
+		match := pending[key][0]
+		pending[key] = pending[key][1:]
+		matched[match] = true
+
+		if fields := compare(old[match], entry); len(fields) > 0 {
+			result.Modified = append(result.Modified, Change{Old: old[match], New: entry, Fields: fields})
+		}
+	}
+
+	for i, entry := range old {
+		if !matched[i] {
+			result.Removed = append(result.Removed, entry)
+		}
+	}
+
+	return result
+}
+
+func (d Differ) key(e Entry) (string, bool) {
+	f := d.Fingerprinter.Compute(e.Identity)
+	return f.Key, f.Strategy != identity.None
+}
+
+func compare(old, new Entry) []Field {
+	var fields []Field
+
+	if old.Title != new.Title {
+		fields = append(fields, Title)
+	}
+
+	if old.Content != new.Content {
+		fields = append(fields, Content)
+	}
+
+	if !old.Updated.Equal(new.Updated) {
+		fields = append(fields, Updated)
+	}
+
+	if strings.Join(old.Links, "\n") != strings.Join(new.Links, "\n") {
+		fields = append(fields, Links)
+	}
+
+	if strings.Join(old.Enclosures, "\n") != strings.Join(new.Enclosures, "\n") {
+		fields = append(fields, Enclosures)
+	}
+
+	return fields
+}
+
+// AtomFeeds compares the entries of two snapshots of a feed. A nil feed has no
+// entries, e.g. old on a first fetch.
+func (d Differ) AtomFeeds(old, new *atom.Feed) Result {
+	return d.Entries(atomEntries(old), atomEntries(new))
+}
+
+// RssChannels compares the items of two snapshots of a channel. A nil channel
+// has no items.
+func (d Differ) RssChannels(old, new *rss.Channel) Result {
+	return d.Entries(rssItems(old), rssItems(new))
+}
+
+func (d Differ) BasicFeeds(old, new feed.BasicFeed) Result {
+	return d.Entries(basicEntries(old), basicEntries(new))
+}
+
+// AtomFeeds is a shortcut to DefaultDiffer.AtomFeeds
+func AtomFeeds(old, new *atom.Feed) Result {
+	return DefaultDiffer.AtomFeeds(old, new)
+}
+
+// RssChannels is a shortcut to DefaultDiffer.RssChannels
+func RssChannels(old, new *rss.Channel) Result {
+	return DefaultDiffer.RssChannels(old, new)
+}
+
+// BasicFeeds is a shortcut to DefaultDiffer.BasicFeeds
+func BasicFeeds(old, new feed.BasicFeed) Result {
+	return DefaultDiffer.BasicFeeds(old, new)
+}
+
+func atomEntries(f *atom.Feed) []Entry {
+	if f == nil {
+		return nil
+	}
+
+	entries := make([]Entry, len(f.Entries))
+	for i, e := range f.Entries {
+		entries[i] = FromAtomEntry(i, e)
+	}
+	return entries
+}
+
+func rssItems(c *rss.Channel) []Entry {
+	if c == nil {
+		return nil
+	}
+
+	entries := make([]Entry, len(c.Items))
+	for i, item := range c.Items {
+		entries[i] = FromRssItem(i, item)
+	}
+	return entries
+}
+
+func basicEntries(f feed.BasicFeed) []Entry {
+	entries := make([]Entry, len(f.Entries))
+	for i, e := range f.Entries {
+		entries[i] = FromBasicEntry(i, e)
+	}
+	return entries
+}
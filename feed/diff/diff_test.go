@@ -0,0 +1,209 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/identity"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func parseAtomFeed(t *testing.T, s string) *atom.Feed {
+	f := atom.NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom">`+s+`</feed>`), f, &checker, 0); err != nil {
+		t.Fatalf("cannot parse feed: %s", err)
+	}
+
+	return f
+}
+
+func parseRssChannel(t *testing.T, s string) *rss.Channel {
+	c := rss.NewChannel()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(`<channel>`+s+`</channel>`), c, &checker, 0); err != nil {
+		t.Fatalf("cannot parse channel: %s", err)
+	}
+
+	return c
+}
+
+func indexes(entries []Entry) string {
+	var s []string
+	for _, e := range entries {
+		s = append(s, fmt.Sprint(e.Index))
+	}
+	return strings.Join(s, ",")
+}
+
+func changes(changes []Change) string {
+	var s []string
+	for _, c := range changes {
+		s = append(s, fmt.Sprintf("%d>%d%v", c.Old.Index, c.New.Index, c.Fields))
+	}
+	return strings.Join(s, ",")
+}
+
+func TestAtomFeeds(t *testing.T) {
+	old := parseAtomFeed(t, `
+  <entry><id>urn:1</id><title>One</title><updated>2024-01-06T10:00:00Z</updated><summary>first</summary>
+    <link href="http://example.org/1"/></entry>
+  <entry><id>urn:2</id><title>Two</title><updated>2024-01-06T11:00:00Z</updated>
+    <link href="http://example.org/2"/><link rel="enclosure" href="http://example.org/2.mp3" type="audio/mpeg" length="10"/></entry>
+  <entry><id>urn:3</id><title>Three</title><updated>2024-01-06T12:00:00Z</updated></entry>`)
+
+	new := parseAtomFeed(t, `
+  <entry><id>urn:4</id><title>Four</title><updated>2024-01-06T13:00:00Z</updated></entry>
+  <entry><id>urn:2</id><title>Two (fixed)</title><updated>2024-01-06T14:00:00Z</updated>
+    <link rel="enclosure" href="http://example.org/2.mp3" type="audio/mpeg" length="12"/><link href="http://example.org/2"/></entry>
+  <entry><id>urn:1</id><title>One</title><updated>2024-01-06T10:00:00Z</updated><summary>first</summary>
+    <link href="http://example.org/1"/></entry>`)
+
+	result := AtomFeeds(old, new)
+
+	if indexes(result.Added) != "0" {
+		t.Errorf("added should be '0', got '%s'", indexes(result.Added))
+	}
+
+	if indexes(result.Removed) != "2" {
+		t.Errorf("removed should be '2', got '%s'", indexes(result.Removed))
+	}
+
+	if changes(result.Modified) != "1>1[title updated enclosures]" {
+		t.Errorf("modified should be '1>1[title updated enclosures]', got '%s'", changes(result.Modified))
+	}
+
+	if !result.Modified[0].Has(Enclosures) || result.Modified[0].Has(Links) {
+		t.Errorf("Change.Has is invalid for %v", result.Modified[0].Fields)
+	}
+
+	if !AtomFeeds(old, old).IsEmpty() {
+		t.Errorf("a feed compared to itself should have no difference")
+	}
+}
+
+func TestRssChannels(t *testing.T) {
+	old := parseRssChannel(t, `
+  <item><guid>a</guid><title>A</title><description>first</description></item>
+  <item><link>http://example.org/b</link><title>B</title></item>
+  <item><title>C</title><description>no identity but its content</description></item>`)
+
+	new := parseRssChannel(t, `
+  <item><guid>a</guid><title>A</title><description>first, edited</description></item>
+  <item><link>http://example.org/b</link><title>B</title>
+    <enclosure url="http://example.org/b.mp3" length="10" type="audio/mpeg"/></item>
+  <item><title>C</title><description>no identity but its content, edited</description></item>`)
+
+	result := RssChannels(old, new)
+
+	if changes(result.Modified) != "0>0[content],1>1[enclosures]" {
+		t.Errorf("modified should be '0>0[content],1>1[enclosures]', got '%s'", changes(result.Modified))
+	}
+
+	// content hashed identity changes with the content
+	if indexes(result.Added) != "2" || indexes(result.Removed) != "2" {
+		t.Errorf("added and removed should be '2', got '%s' and '%s'", indexes(result.Added), indexes(result.Removed))
+	}
+}
+
+func TestFirstFetch(t *testing.T) {
+	f := parseAtomFeed(t, `<entry><id>urn:1</id><title>One</title></entry><entry><id>urn:2</id><title>Two</title></entry>`)
+	c := parseRssChannel(t, `<item><guid>a</guid><title>A</title></item>`)
+
+	if result := AtomFeeds(nil, f); indexes(result.Added) != "0,1" || len(result.Removed) != 0 || len(result.Modified) != 0 {
+		t.Errorf("every entry should be added, got %+v", result)
+	}
+
+	if result := AtomFeeds(f, nil); indexes(result.Removed) != "0,1" || len(result.Added) != 0 {
+		t.Errorf("every entry should be removed, got %+v", result)
+	}
+
+	if result := RssChannels(nil, c); indexes(result.Added) != "0" || len(result.Removed) != 0 {
+		t.Errorf("every item should be added, got %+v", result)
+	}
+
+	if !RssChannels(nil, nil).IsEmpty() {
+		t.Errorf("nil channels should have no difference")
+	}
+}
+
+func TestEntriesMatching(t *testing.T) {
+	old := []Entry{
+		{Index: 0, Identity: identity.Entry{Id: "dup"}, Title: "first"},
+		{Index: 1, Identity: identity.Entry{Id: "dup"}, Title: "second"},
+		{Index: 2, Identity: identity.Entry{}},
+	}
+
+	new := []Entry{
+		{Index: 0, Identity: identity.Entry{Id: "dup"}, Title: "first"},
+		{Index: 1, Identity: identity.Entry{}},
+	}
+
+	result := DefaultDiffer.Entries(old, new)
+
+	if indexes(result.Added) != "1" || indexes(result.Removed) != "1,2" || len(result.Modified) != 0 {
+		t.Errorf("invalid result: added '%s' removed '%s' modified '%s'", indexes(result.Added), indexes(result.Removed), changes(result.Modified))
+	}
+
+	// matching on links only when ids are not stable
+	byLink := Differ{Fingerprinter: identity.Fingerprinter{Strategies: []identity.Strategy{identity.Link}}}
+	result = byLink.Entries(
+		[]Entry{{Identity: identity.Entry{Id: "urn:fetch1", Link: "http://example.org/1"}}},
+		[]Entry{{Identity: identity.Entry{Id: "urn:fetch2", Link: "http://example.org/1"}}},
+	)
+
+	if !result.IsEmpty() {
+		t.Errorf("entries with the same link should match, got %+v", result)
+	}
+}
+
+func TestBasicFeeds(t *testing.T) {
+	old := feed.BasicFeed{Entries: []feed.BasicEntryBlock{{Id: "a", Title: "A", Links: feed.BasicLinks{{Rel: "alternate", Href: "http://example.org/a"}}}}}
+	new := feed.BasicFeed{Entries: []feed.BasicEntryBlock{{Id: "a", Title: "A", Links: feed.BasicLinks{{Rel: "alternate", Href: "http://example.org/a2"}}}}}
+
+	if changes(BasicFeeds(old, new).Modified) != "0>0[links]" {
+		t.Errorf("modified should be '0>0[links]', got '%s'", changes(BasicFeeds(old, new).Modified))
+	}
+}
+
+// basicAtomFeed is f as populated by the feed package
+func basicAtomFeed(f *atom.Feed) feed.BasicFeed {
+	b := feed.BasicFeed{}
+	b.PopulateFromAtomFeed(f)
+	for _, e := range f.Entries {
+		b.PopulateFromAtomEntry(e)
+	}
+	return b
+}
+
+func TestBasicFeedsMatchAtomFeeds(t *testing.T) {
+	old := parseAtomFeed(t, `
+  <entry><id>urn:1</id><title>One</title><updated>2024-01-06T10:00:00Z</updated><summary>s</summary><content>first</content>
+    <link href="http://example.org/1"/><link rel="replies" href="http://example.org/1/comments"/></entry>
+  <entry><title>Two</title><updated>2024-01-06T11:00:00Z</updated><summary>s</summary><content>second</content>
+    <link rel="enclosure" href="http://example.org/2.mp3" type="audio/mpeg" length="10"/></entry>`)
+
+	new := parseAtomFeed(t, `
+  <entry><id>urn:1</id><title>One</title><updated>2024-01-06T10:00:00Z</updated><summary>s</summary><content>first, edited</content>
+    <link href="http://example.org/1"/><link rel="replies" href="http://example.org/1/all-comments"/></entry>
+  <entry><title>Two</title><updated>2024-01-06T11:00:00Z</updated><summary>s</summary><content>second</content>
+    <link rel="enclosure" href="http://example.org/2.mp3" type="audio/mpeg" length="10"/></entry>`)
+
+	atomResult := AtomFeeds(old, new)
+	basicResult := BasicFeeds(basicAtomFeed(old), basicAtomFeed(new))
+
+	if changes(atomResult.Modified) != "0>0[content links]" {
+		t.Errorf("modified should be '0>0[content links]', got '%s'", changes(atomResult.Modified))
+	}
+
+	if changes(basicResult.Modified) != changes(atomResult.Modified) || basicResult.IsEmpty() != atomResult.IsEmpty() ||
+		indexes(basicResult.Added) != indexes(atomResult.Added) || indexes(basicResult.Removed) != indexes(atomResult.Removed) {
+		t.Errorf("basic and atom diffs differ: %+v vs %+v", basicResult, atomResult)
+	}
+}
@@ -0,0 +1,404 @@
+package extension
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jloup/utils"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// ValueType is the type of the value held by a declared extension
+type ValueType int
+
+const (
+	StringValue ValueType = iota
+	IntValue
+	DateValue
+	IriValue
+	EnumValue
+)
+
+var valueTypeNames = []string{"string", "int", "date", "iri", "enum"}
+
+func (v ValueType) String() string {
+	if int(v) < len(valueTypeNames) {
+		return valueTypeNames[v]
+	}
+	return fmt.Sprintf("ValueType(%d)", int(v))
+}
+
+func (v *ValueType) UnmarshalText(text []byte) error {
+	for i, name := range valueTypeNames {
+		if name == string(text) {
+			*v = ValueType(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown value type '%s'", text)
+}
+
+// Cardinality is the number of times a declared extension element may appear
+// in its parent
+type Cardinality int
+
+const (
+	ZeroOrMore Cardinality = iota
+	ZeroOrOne
+	OneOrMore
+	ExactlyOne
+)
+
+var cardinalityNames = []string{"zero-or-more", "zero-or-one", "one-or-more", "exactly-one"}
+
+func (c Cardinality) String() string {
+	if int(c) < len(cardinalityNames) {
+		return cardinalityNames[c]
+	}
+	return fmt.Sprintf("Cardinality(%d)", int(c))
+}
+
+func (c *Cardinality) UnmarshalText(text []byte) error {
+	for i, name := range cardinalityNames {
+		if name == string(text) {
+			*c = Cardinality(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown cardinality '%s'", text)
+}
+
+// Field declares an extension element or attribute. Parents are the tag names
+// the field is registered on, as passed to Manager.AddElementExtension.
+// Attributes are always unique in their parent: their Cardinality is ignored.
+type Field struct {
+	Name        string      `json:"name"`
+	Attr        bool        `json:"attr"`
+	Parents     []string    `json:"parents"`
+	Cardinality Cardinality `json:"cardinality"`
+	Type        ValueType   `json:"type"`
+	Enum        []string    `json:"enum"`
+
+	Validators []xmlutils.ElementValidator `json:"-"`
+}
+
+// Definition declares the elements and attributes of an extension namespace.
+// Flags are the error flags raised by the values and occurences checks.
+type Definition struct {
+	Namespace string  `json:"namespace"`
+	Fields    []Field `json:"fields"`
+
+	DuplicatedFlag utils.Flag `json:"-"`
+	MissingFlag    utils.Flag `json:"-"`
+	InvalidFlag    utils.Flag `json:"-"`
+}
+
+func NewDefinition(namespace string, fields ...Field) Definition {
+	return Definition{
+		Namespace: namespace,
+		Fields:    fields,
+
+		DuplicatedFlag: ExtensionDuplicated,
+		MissingFlag:    MissingExtension,
+		InvalidFlag:    ExtensionValueNotValid,
+	}
+}
+
+// LoadDefinition reads a JSON definition, e.g.
+//
+//	{
+//	  "namespace": "http://www.youtube.com/xml/schemas/2015",
+//	  "fields": [
+//	    {"name": "videoId", "parents": ["entry"], "cardinality": "exactly-one", "type": "string"}
+//	  ]
+//	}
+func LoadDefinition(r io.Reader) (Definition, error) {
+	d := NewDefinition("")
+
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return d, err
+	}
+
+	if d.Namespace == "" {
+		return d, fmt.Errorf("definition has no namespace")
+	}
+
+	for _, field := range d.Fields {
+		if field.Name == "" {
+			return d, fmt.Errorf("definition of '%s' has a field without name", d.Namespace)
+		}
+		if len(field.Parents) == 0 {
+			return d, fmt.Errorf("field '%s' has no parent", field.Name)
+		}
+	}
+
+	return d, nil
+}
+
+// xmlName returns the name of a field as reported by the walker, which
+// lowercases names
+func (d *Definition) xmlName(name string) xml.Name {
+	return xml.Name{Space: strings.ToLower(d.Namespace), Local: strings.ToLower(name)}
+}
+
+func (d *Definition) occurenceValidator(c Cardinality) xmlutils.OccurenceValidator {
+	switch c {
+	case ZeroOrOne:
+		return xmlutils.UniqueValidator(d.DuplicatedFlag)
+	case OneOrMore:
+		return xmlutils.ExistsValidator(d.MissingFlag)
+	case ExactlyOne:
+		return xmlutils.ExistsAndUniqueValidator(d.MissingFlag, d.DuplicatedFlag)
+	}
+
+	return func(o *xmlutils.Occurence) xmlutils.ParserError { return nil }
+}
+
+// AddDefinition registers every field of d in the manager
+func (m *Manager) AddDefinition(d Definition) error {
+	for i := range d.Fields {
+		field := d.Fields[i]
+		name := d.xmlName(field.Name)
+		validator := d.valueValidator(field)
+
+		for _, parent := range field.Parents {
+			var err error
+
+			if field.Attr {
+				err = m.AddAttrExtension(parent, name, func() Attr {
+					return newAttrValue(name, validator)
+				}, d.DuplicatedFlag)
+			} else {
+				err = m.AddElementExtension(parent, name, func() Element {
+					return newValue(name, validator)
+				}, d.occurenceValidator(field.Cardinality))
+			}
+
+			if err != nil {
+				return fmt.Errorf("cannot register '%s' in '%s': %s", field.Name, parent, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+var dateLayouts = []string{time.RFC3339, time.RFC1123Z, time.RFC1123}
+
+func parseDate(s string) (time.Time, error) {
+	var t time.Time
+	var err error
+
+	for _, layout := range dateLayouts {
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return t, err
+}
+
+func (d *Definition) valueValidator(field Field) xmlutils.ElementValidator {
+	f := d.InvalidFlag
+	isAbsoluteIri := xmlutils.IsValidAbsoluteIri(f)
+
+	return func(name, s string) xmlutils.ParserError {
+		switch field.Type {
+		case IntValue:
+			if _, err := strconv.Atoi(s); err != nil {
+				return xmlutils.NewError(f, fmt.Sprintf("%s '%s' is not an integer", name, s))
+			}
+
+		case DateValue:
+			if _, err := parseDate(s); err != nil {
+				return xmlutils.NewError(f, fmt.Sprintf("%s '%s' is not a date", name, s))
+			}
+
+		case IriValue:
+			if err := isAbsoluteIri(name, s); err != nil {
+				return err
+			}
+
+		case EnumValue:
+			valid := false
+			for _, v := range field.Enum {
+				if v == s {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return xmlutils.NewError(f, fmt.Sprintf("%s '%s' should be one of %s", name, s, strings.Join(field.Enum, ", ")))
+			}
+		}
+
+		for _, validator := range field.Validators {
+			if err := validator(name, s); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Value is the element built for a declared extension element
+type Value struct {
+	Content   string
+	name      xml.Name
+	Validator xmlutils.ElementValidator
+
+	Parent xmlutils.Visitor
+	depth  xmlutils.DepthWatcher
+	// text is the raw char data, trimmed into Content at the end of the element
+	text string
+}
+
+func newValue(name xml.Name, validator xmlutils.ElementValidator) *Value {
+	return &Value{name: name, Validator: validator, depth: xmlutils.NewDepthWatcher()}
+}
+
+func (v *Value) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	v.depth.Down()
+	return v, nil
+}
+
+func (v *Value) ProcessEndElement(el xml.EndElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if v.depth.Up() == xmlutils.RootLevel {
+		v.Content = strings.TrimSpace(v.text)
+		return v.Parent, v.Validate()
+	}
+
+	return v, nil
+}
+
+func (v *Value) ProcessCharData(el xml.CharData) (xmlutils.Visitor, xmlutils.ParserError) {
+	if v.depth.Level == 1 {
+		v.text += string(el)
+	}
+	return v, nil
+}
+
+func (v *Value) Name() xml.Name {
+	return v.name
+}
+
+func (v *Value) String() string {
+	return v.Content
+}
+
+func (v *Value) SetParent(p xmlutils.Visitor) {
+	v.Parent = p
+}
+
+func (v *Value) Validate() xmlutils.ParserError {
+	return v.Validator(v.name.Local, v.Content)
+}
+
+// AttrValue is the attribute built for a declared extension attribute
+type AttrValue struct {
+	BasicAttr
+}
+
+func newAttrValue(name xml.Name, validator xmlutils.ElementValidator) *AttrValue {
+	return &AttrValue{NewBasicAttr(name, validator)}
+}
+
+// StringField gives access to the values of a declared string or enum field
+type StringField struct {
+	name xml.Name
+}
+
+func (d Definition) StringField(name string) StringField {
+	return StringField{d.xmlName(name)}
+}
+
+// Get returns the first value of the field in store
+func (f StringField) Get(store *Store) (string, bool) {
+	return store.Get(f.name)
+}
+
+// GetAll returns every values of the field in store
+func (f StringField) GetAll(store *Store) []string {
+	var values []string
+
+	collection, _ := store.GetCollection(f.name)
+	for _, ext := range collection {
+		values = append(values, ext.String())
+	}
+
+	return values
+}
+
+// IntField gives access to the values of a declared int field
+type IntField struct {
+	name xml.Name
+}
+
+func (d Definition) IntField(name string) IntField {
+	return IntField{d.xmlName(name)}
+}
+
+// Get returns the first value of the field in store. ok is false if the value
+// is missing or is not an integer.
+func (f IntField) Get(store *Store) (int, bool) {
+	s, ok := store.Get(f.name)
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+// DateField gives access to the values of a declared date field
+type DateField struct {
+	name xml.Name
+}
+
+func (d Definition) DateField(name string) DateField {
+	return DateField{d.xmlName(name)}
+}
+
+// Get returns the first value of the field in store. ok is false if the value
+// is missing or is not a date.
+func (f DateField) Get(store *Store) (time.Time, bool) {
+	s, ok := store.Get(f.name)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, err := parseDate(s)
+	return t, err == nil
+}
+
+// IriField gives access to the values of a declared IRI field
+type IriField struct {
+	name xml.Name
+}
+
+func (d Definition) IriField(name string) IriField {
+	return IriField{d.xmlName(name)}
+}
+
+// Get returns the first value of the field in store. ok is false if the value
+// is missing or is not an absolute IRI.
+func (f IriField) Get(store *Store) (*url.URL, bool) {
+	s, ok := store.Get(f.name)
+	if !ok {
+		return nil, false
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || !u.IsAbs() {
+		return nil, false
+	}
+
+	return u, true
+}
@@ -0,0 +1,184 @@
+package extension
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+const testDefinitionJSON = `{
+  "namespace": "http://example.org/ext",
+  "fields": [
+    {"name": "videoId", "parents": ["parent"], "cardinality": "exactly-one", "type": "string"},
+    {"name": "views", "parents": ["parent"], "cardinality": "zero-or-one", "type": "int"},
+    {"name": "published", "parents": ["parent"], "type": "date"},
+    {"name": "home", "parents": ["parent"], "type": "iri"},
+    {"name": "quality", "parents": ["parent"], "type": "enum", "enum": ["sd", "hd"]},
+    {"name": "tag", "parents": ["parent"], "cardinality": "zero-or-more"},
+    {"name": "flag", "attr": true, "parents": ["parent"], "type": "enum", "enum": ["on", "off"]}
+  ]
+}`
+
+type testDefinition struct {
+	XML           string
+	ExpectedError xmlutils.ParserError
+}
+
+func testDefinitionManager(t *testing.T) (Manager, Definition) {
+	d, err := LoadDefinition(strings.NewReader(testDefinitionJSON))
+	if err != nil {
+		t.Fatalf("cannot load definition: %s", err)
+	}
+
+	manager := Manager{}
+	if err := manager.AddDefinition(d); err != nil {
+		t.Fatalf("cannot add definition: %s", err)
+	}
+
+	return manager, d
+}
+
+func TestDefinitionValues(t *testing.T) {
+	manager, d := testDefinitionManager(t)
+
+	p := newTestParent(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+	<parent xmlns:ext="http://example.org/ext" ext:flag="on">
+	  <ext:videoId>aF4JE5XmkfY</ext:videoId>
+	  <ext:views>42</ext:views>
+	  <ext:published>2015-11-04T08:00:01+00:00</ext:published>
+	  <ext:home>http://example.org/home</ext:home>
+	  <ext:quality>hd</ext:quality>
+	  <ext:tag>a</ext:tag>
+	  <ext:tag> Hello <!-- c -->world </ext:tag>
+	</parent>`), p, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	store := &p.Extension.Store
+
+	if v, ok := d.StringField("videoId").Get(store); !ok || v != "aF4JE5XmkfY" {
+		t.Errorf("videoId is invalid '%s' (expected) vs '%s'", "aF4JE5XmkfY", v)
+	}
+
+	if v, ok := d.IntField("views").Get(store); !ok || v != 42 {
+		t.Errorf("views is invalid '%v' (expected) vs '%v'", 42, v)
+	}
+
+	expectedDate := time.Date(2015, 11, 4, 8, 0, 1, 0, time.UTC)
+	if v, ok := d.DateField("published").Get(store); !ok || !v.Equal(expectedDate) {
+		t.Errorf("published is invalid '%v' (expected) vs '%v'", expectedDate, v)
+	}
+
+	if v, ok := d.IriField("home").Get(store); !ok || v.Host != "example.org" {
+		t.Errorf("home is invalid '%v' (expected) vs '%v'", "example.org", v)
+	}
+
+	if v := d.StringField("tag").GetAll(store); len(v) != 2 || v[0] != "a" || v[1] != "Hello world" {
+		t.Errorf("tags are invalid '%v' (expected) vs '%v'", []string{"a", "Hello world"}, v)
+	}
+
+	if v, ok := d.StringField("flag").Get(store); !ok || v != "on" {
+		t.Errorf("flag is invalid '%s' (expected) vs '%s'", "on", v)
+	}
+
+	if _, ok := d.IntField("videoId").Get(store); ok {
+		t.Errorf("videoId should not be readable as an int")
+	}
+}
+
+func TestDefinitionRelativeIri(t *testing.T) {
+	manager, d := testDefinitionManager(t)
+
+	p := newTestParent(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+	<parent xmlns:ext="http://example.org/ext">
+	  <ext:videoId>aF4JE5XmkfY</ext:videoId>
+	  <ext:home>/home</ext:home>
+	</parent>`), p, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v, ok := d.IriField("home").Get(&p.Extension.Store); ok {
+		t.Errorf("relative home '%v' should not be readable as an IRI", v)
+	}
+}
+
+func TestDefinitionErrors(t *testing.T) {
+	manager, _ := testDefinitionManager(t)
+
+	var testdata = []testDefinition{
+		{`<parent xmlns:ext="http://example.org/ext"><ext:videoId>a</ext:videoId></parent>`,
+			nil,
+		},
+		{`<parent xmlns:ext="http://example.org/ext"></parent>`,
+			xmlutils.NewError(MissingExtension, ""),
+		},
+		{`<parent xmlns:ext="http://example.org/ext"><ext:videoId>a</ext:videoId><ext:videoId>b</ext:videoId></parent>`,
+			xmlutils.NewError(ExtensionDuplicated, ""),
+		},
+		{`<parent xmlns:ext="http://example.org/ext"><ext:videoId>a</ext:videoId><ext:views>4s</ext:views></parent>`,
+			xmlutils.NewError(ExtensionValueNotValid, ""),
+		},
+		{`<parent xmlns:ext="http://example.org/ext"><ext:videoId>a</ext:videoId><ext:published>yesterday</ext:published></parent>`,
+			xmlutils.NewError(ExtensionValueNotValid, ""),
+		},
+		{`<parent xmlns:ext="http://example.org/ext"><ext:videoId>a</ext:videoId><ext:home>/home</ext:home></parent>`,
+			xmlutils.NewError(ExtensionValueNotValid, ""),
+		},
+		{`<parent xmlns:ext="http://example.org/ext"><ext:videoId>a</ext:videoId><ext:quality>4k</ext:quality></parent>`,
+			xmlutils.NewError(ExtensionValueNotValid, ""),
+		},
+		{`<parent xmlns:ext="http://example.org/ext" ext:flag="maybe"><ext:videoId>a</ext:videoId></parent>`,
+			xmlutils.NewError(ExtensionValueNotValid, ""),
+		},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, testdefinition := range testdata {
+		testcase := xmlutils.TestVisitor{
+			XML:           testdefinition.XML,
+			ExpectedError: testdefinition.ExpectedError,
+			VisitorConstructor: func() xmlutils.Visitor {
+				return newTestParent(manager)
+			},
+			Validator: func(actual xmlutils.Visitor, expected xmlutils.Visitor) error {
+				return nil
+			},
+		}
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
+
+func TestLoadDefinitionErrors(t *testing.T) {
+	var testdata = []string{
+		`{"fields": [{"name": "a", "parents": ["entry"]}]}`,
+		`{"namespace": "http://example.org/ext", "fields": [{"parents": ["entry"]}]}`,
+		`{"namespace": "http://example.org/ext", "fields": [{"name": "a"}]}`,
+		`{"namespace": "http://example.org/ext", "fields": [{"name": "a", "parents": ["entry"], "type": "float"}]}`,
+		`{"namespace": "http://example.org/ext", "fields": [{"name": "a", "parents": ["entry"], "cardinality": "many"}]}`,
+	}
+
+	for _, s := range testdata {
+		if _, err := LoadDefinition(strings.NewReader(s)); err == nil {
+			t.Errorf("definition should not be loaded:\n%s", s)
+		}
+	}
+}
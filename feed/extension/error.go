@@ -0,0 +1,12 @@
+package extension
+
+import (
+	"github.com/jloup/utils"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+var (
+	ExtensionDuplicated    = utils.InitFlag(&xmlutils.ErrorFlagCounter, "ExtensionDuplicated")
+	MissingExtension       = utils.InitFlag(&xmlutils.ErrorFlagCounter, "MissingExtension")
+	ExtensionValueNotValid = utils.InitFlag(&xmlutils.ErrorFlagCounter, "ExtensionValueNotValid")
+)
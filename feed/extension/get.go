@@ -0,0 +1,55 @@
+package extension
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is returned when a store holds no extension of the requested name
+	ErrNotFound = errors.New("extension not found")
+	// ErrWrongType is returned when an extension is not of the requested type
+	ErrWrongType = errors.New("extension has not the requested type")
+)
+
+// Get returns the first extension named name in store. The error wraps
+// ErrNotFound if there is no such extension and ErrWrongType if it is not a T.
+func Get[T any](store *Store, name xml.Name) (T, error) {
+	var zero T
+
+	i := store.find(name)
+	if i == -1 {
+		return zero, fmt.Errorf("%w: %s", ErrNotFound, xmlNameToString(name))
+	}
+
+	ext := store.stores[i].extensions[0]
+	v, ok := ext.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s is %T, not %T", ErrWrongType, xmlNameToString(name), ext, zero)
+	}
+
+	return v, nil
+}
+
+// GetAll returns every extensions named name in store, in document order. The
+// error wraps ErrNotFound if there is no such extension and ErrWrongType if
+// one of them is not a T.
+func GetAll[T any](store *Store, name xml.Name) ([]T, error) {
+	i := store.find(name)
+	if i == -1 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, xmlNameToString(name))
+	}
+
+	values := make([]T, 0, len(store.stores[i].extensions))
+	for _, ext := range store.stores[i].extensions {
+		v, ok := ext.(T)
+		if !ok {
+			var zero T
+			return nil, fmt.Errorf("%w: %s is %T, not %T", ErrWrongType, xmlNameToString(name), ext, zero)
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
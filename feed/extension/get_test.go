@@ -0,0 +1,79 @@
+package extension
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+var (
+	testGetName  = xml.Name{Space: "http://example.org/ext", Local: "a"}
+	testGetNameB = xml.Name{Space: "http://example.org/ext", Local: "b"}
+)
+
+func newTestGetStore() *Store {
+	s := &Store{}
+
+	first := NewNode()
+	first.Content = []NodeContent{{Text: "first"}}
+	second := NewNode()
+	second.Content = []NodeContent{{Text: "second"}}
+
+	s.Add(testGetName, first)
+	s.Add(testGetName, second)
+	s.Add(testGetNameB, newUnknownAttr(testGetNameB))
+
+	return s
+}
+
+func TestGet(t *testing.T) {
+	s := newTestGetStore()
+
+	node, err := Get[*Node](s, testGetName)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if node.String() != "first" {
+		t.Errorf("Get should return the first extension '%s' (expected) vs '%s'", "first", node.String())
+	}
+
+	if _, err := Get[*Node](s, xml.Name{Local: "missing"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get should return ErrNotFound, got %v", err)
+	}
+
+	if _, err := Get[*Node](s, testGetNameB); !errors.Is(err, ErrWrongType) {
+		t.Errorf("Get should return ErrWrongType, got %v", err)
+	}
+
+	if attr, err := Get[Attr](s, testGetNameB); err != nil || attr.Name() != testGetNameB {
+		t.Errorf("Get should accept interface types, got %v", err)
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	s := newTestGetStore()
+
+	nodes, err := GetAll[*Node](s, testGetName)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(nodes) != 2 || nodes[0].String() != "first" || nodes[1].String() != "second" {
+		t.Errorf("GetAll should return every extensions in order")
+	}
+
+	if _, err := GetAll[*Node](s, xml.Name{Local: "missing"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetAll should return ErrNotFound, got %v", err)
+	}
+
+	if _, err := GetAll[*UnknownAttr](s, testGetName); !errors.Is(err, ErrWrongType) {
+		t.Errorf("GetAll should return ErrWrongType, got %v", err)
+	}
+}
+
+func TestGetItfMissing(t *testing.T) {
+	s := newTestGetStore()
+
+	if itf, ok := s.GetItf(xml.Name{Local: "missing"}); ok || itf != nil {
+		t.Errorf("GetItf should return nil for a missing extension, got %#v", itf)
+	}
+}
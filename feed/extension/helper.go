@@ -2,6 +2,7 @@ package extension
 
 import (
 	"encoding/xml"
+	"strings"
 
 	"github.com/jloup/utils"
 	xmlutils "github.com/jloup/xml/utils"
@@ -14,11 +15,15 @@ type VisitorExtension struct {
 	Store      Store
 }
 
-func InitExtension(name string, manager Manager) VisitorExtension {
-	v := VisitorExtension{name: name, Manager: manager}
-	v.Repository = manager.GetRepo(name)
+// InitExtension builds the extension hook of the element at path (see
+// xmlutils.StartElement.Path), or of a bare tag name. The Manager is only read:
+// occurences are counted on a copy of its registry. See Manager.GetRepo for
+// aliases.
+func InitExtension(path string, manager Manager, aliases ...string) VisitorExtension {
+	v := VisitorExtension{name: path[strings.LastIndex(path, "/")+1:], Manager: manager}
+	v.Repository = manager.GetRepo(path, aliases...)
 
-	v.Store.Occ = v.Repository.Occ
+	v.Store.Occ = v.Repository.Occ.Clone()
 	v.Store.Occ.Reset()
 
 	return v
@@ -26,7 +31,17 @@ func InitExtension(name string, manager Manager) VisitorExtension {
 
 func (v *VisitorExtension) ProcessElement(el xmlutils.StartElement, parent xmlutils.Visitor) (xmlutils.Visitor, xmlutils.ParserError) {
 	if constructor := v.Repository.GetElement(el.Name); constructor == nil {
-		return nil, nil
+		if !v.Manager.captureUnknown {
+			return nil, nil
+		}
+
+		node := newNodeElement()
+		node.SetParent(parent)
+
+		nextV, err := node.ProcessStartElement(el)
+
+		v.Store.Add(node.Name(), node)
+		return nextV, err
 
 	} else {
 		ext := constructor()
@@ -46,6 +61,12 @@ func (v *VisitorExtension) ProcessAttr(attr xml.Attr, parent xmlutils.Visitor) {
 		ext.SetParent(parent)
 		ext.Set(attr.Value)
 		v.Store.Add(ext.Name(), ext)
+
+	} else if v.Manager.captureUnknown && isForeignAttr(attr.Name) {
+		ext := newUnknownAttr(attr.Name)
+		ext.SetParent(parent)
+		ext.Set(attr.Value)
+		v.Store.Add(ext.Name(), ext)
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jloup/utils"
 
@@ -98,8 +99,37 @@ func (r *Repository) GetElement(name xml.Name) ElementConstructor {
 *
  */
 
+// Manager holds the registered extensions. Registering is not safe for
+// concurrent use, but a Manager is only read while parsing, so once set up it
+// can be shared by any number of concurrent parses.
 type Manager struct {
-	tags []Repository
+	tags           []Repository
+	captureUnknown bool
+}
+
+// CaptureUnknown enables/disables the capture of extension elements and
+// attributes which are not registered in the Manager. Captured extensions are
+// stored as *Node and *UnknownAttr in their parent's Store. When disabled
+// (default), they are skipped.
+func (m *Manager) CaptureUnknown(enable bool) {
+	m.captureUnknown = enable
+}
+
+// CapturesUnknown reports whether unknown extensions are captured
+func (m *Manager) CapturesUnknown() bool {
+	return m.captureUnknown
+}
+
+// Clone returns a copy of m: extensions registered on the copy are not
+// registered on m
+func (m Manager) Clone() Manager {
+	c := Manager{captureUnknown: m.captureUnknown}
+
+	for _, tag := range m.tags {
+		c.tags = append(c.tags, tag.clone())
+	}
+
+	return c
 }
 
 func (m *Manager) findAndCreate(name string) int {
@@ -123,20 +153,102 @@ func (m *Manager) find(name string) int {
 	return -1
 }
 
+// AddAttrExtension registers an attribute extension on the elements matching
+// tagName. tagName is a selector: a tag name ("link") or a slash separated
+// path of tag names ("entry/link"). A selector matches every element whose
+// path ends with it; a leading "/" anchors it to the document root
+// ("/feed/link" only matches feed level links).
 func (m *Manager) AddAttrExtension(tagName string, name xml.Name, constructor AttrConstructor, attrDuplicatedFlag utils.Flag) error {
-	index := m.findAndCreate(tagName)
+	index := m.findAndCreate(strings.ToLower(tagName))
 	return m.tags[index].AddAttr(name, constructor, attrDuplicatedFlag)
 }
 
+// AddElementExtension registers an element extension on the elements matching
+// tagName. See AddAttrExtension for the selector syntax.
 func (m *Manager) AddElementExtension(tagName string, name xml.Name, constructor ElementConstructor, occValidator xmlutils.OccurenceValidator) error {
-	index := m.findAndCreate(tagName)
+	index := m.findAndCreate(strings.ToLower(tagName))
 	return m.tags[index].AddElement(name, constructor, occValidator)
 }
 
-func (m *Manager) GetRepo(tagName string) Repository {
-	if index := m.find(tagName); index == -1 {
-		return Repository{}
-	} else {
-		return m.tags[index]
+// GetRepo returns the extensions registered on the element at path. When
+// several selectors match, their extensions are merged; the first registered
+// one wins on name conflicts.
+//
+// aliases are the legacy names of the construct parsed at path, e.g. "person"
+// or "date": extensions registered under one of them apply as well.
+func (m *Manager) GetRepo(path string, aliases ...string) Repository {
+	var repo Repository
+	matches := 0
+
+	for _, tag := range m.tags {
+		if !matchSelector(tag.name, path) && !matchAlias(tag.name, aliases) {
+			continue
+		}
+
+		matches++
+		if matches == 1 {
+			repo = tag
+			continue
+		}
+
+		if matches == 2 {
+			repo = repo.clone()
+		}
+		repo.merge(tag)
 	}
+
+	return repo
+}
+
+func matchSelector(selector, path string) bool {
+	if strings.HasPrefix(selector, "/") {
+		return selector[1:] == path
+	}
+
+	return path == selector || strings.HasSuffix(path, "/"+selector)
+}
+
+func matchAlias(selector string, aliases []string) bool {
+	for _, alias := range aliases {
+		if selector == strings.ToLower(alias) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Repository) clone() Repository {
+	c := Repository{name: r.name}
+
+	c.elements = append(c.elements, r.elements...)
+	c.attrs = append(c.attrs, r.attrs...)
+	c.Occ.Occurences = append(c.Occ.Occurences, r.Occ.Occurences...)
+
+	return c
+}
+
+func (r *Repository) merge(other Repository) {
+	for _, el := range other.elements {
+		if r.findElement(el.name) == -1 {
+			r.elements = append(r.elements, el)
+			r.Occ.AddOccurence(other.occurence(el.name))
+		}
+	}
+
+	for _, attr := range other.attrs {
+		if r.findAttr(attr.name) == -1 {
+			r.attrs = append(r.attrs, attr)
+			r.Occ.AddOccurence(other.occurence(attr.name))
+		}
+	}
+}
+
+func (r *Repository) occurence(name xml.Name) *xmlutils.Occurence {
+	key := xmlNameToString(name)
+	for _, occ := range r.Occ.Occurences {
+		if occ.Name == key {
+			return occ
+		}
+	}
+	return nil
 }
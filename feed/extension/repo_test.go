@@ -0,0 +1,71 @@
+package extension
+
+import (
+	"encoding/xml"
+	"testing"
+
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func TestMatchSelector(t *testing.T) {
+	var testdata = []struct {
+		Selector string
+		Path     string
+		Match    bool
+	}{
+		{"entry", "feed/entry", true},
+		{"entry", "entry", true},
+		{"author", "feed/entry/author", true},
+		{"entry/author", "feed/entry/author", true},
+		{"entry/author", "feed/author", false},
+		{"entry/author", "feed/entry/contributor", false},
+		{"author", "feed/entry/coauthor", false},
+		{"channel/image", "rss/channel/image", true},
+		{"channel/image", "rss/channel/item/image", false},
+		{"/feed/link", "feed/link", true},
+		{"/feed/link", "feed/entry/link", false},
+		{"/link", "feed/link", false},
+	}
+
+	for _, test := range testdata {
+		if match := matchSelector(test.Selector, test.Path); match != test.Match {
+			t.Errorf("selector '%s' on path '%s' should match: %v (expected) vs %v", test.Selector, test.Path, test.Match, match)
+		}
+	}
+}
+
+func TestGetRepoMerge(t *testing.T) {
+	a := xml.Name{Space: "http://example.org/ext", Local: "a"}
+	b := xml.Name{Space: "http://example.org/ext", Local: "b"}
+
+	manager := Manager{}
+	manager.AddElementExtension("author", a, nil, xmlutils.UniqueValidator(ExtensionDuplicated))
+	manager.AddElementExtension("entry/author", b, nil, xmlutils.UniqueValidator(ExtensionDuplicated))
+	manager.AddElementExtension("Entry/Author", a, nil, xmlutils.UniqueValidator(ExtensionDuplicated))
+
+	repo := manager.GetRepo("feed/entry/author")
+	if len(repo.elements) != 2 || repo.findElement(a) == -1 || repo.findElement(b) == -1 {
+		t.Errorf("entry author should get both extensions, got %v", repo.elements)
+	}
+	if len(repo.Occ.Occurences) != 2 {
+		t.Errorf("entry author should get 2 occurences, got %v", len(repo.Occ.Occurences))
+	}
+
+	repo = manager.GetRepo("feed/author")
+	if len(repo.elements) != 1 || repo.findElement(a) == -1 {
+		t.Errorf("feed author should only get the 'author' extension, got %v", repo.elements)
+	}
+
+	if repo := manager.GetRepo("feed/author"); len(repo.elements) != 1 {
+		t.Errorf("merging must not alter the registered repositories, got %v", repo.elements)
+	}
+
+	manager.AddElementExtension("Person", b, nil, xmlutils.UniqueValidator(ExtensionDuplicated))
+	if repo := manager.GetRepo("feed/contributor", "person"); len(repo.elements) != 1 || repo.findElement(b) == -1 {
+		t.Errorf("contributor should get the extension registered under its legacy name, got %v", repo.elements)
+	}
+
+	if repo := manager.GetRepo("feed/contributor"); len(repo.elements) != 0 {
+		t.Errorf("contributor should not get any extension, got %v", repo.elements)
+	}
+}
@@ -66,7 +66,7 @@ func (s *Store) GetItf(name xml.Name) (interface{}, bool) {
 		return s.stores[i].extensions[0], true
 	}
 
-	return "", false
+	return nil, false
 }
 
 func (s *Store) GetCollection(name xml.Name) ([]storeInterface, bool) {
@@ -89,3 +89,65 @@ func (s *Store) Validate(errorAgg *utils.ErrorAggregator) {
 		}
 	}
 }
+
+// Unknown returns the captured unknown elements which belong to namespace
+// space. An empty space matches every namespace. Elements are grouped by name.
+func (s *Store) Unknown(space string) []*Node {
+	var nodes []*Node
+
+	for _, store := range s.stores {
+		if space != "" && store.name.Space != space {
+			continue
+		}
+
+		for _, ext := range store.extensions {
+			if node, ok := ext.(*Node); ok {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// UnknownAttrs returns the captured unknown attributes which belong to
+// namespace space. An empty space matches every namespace.
+func (s *Store) UnknownAttrs(space string) []*UnknownAttr {
+	var attrs []*UnknownAttr
+
+	for _, store := range s.stores {
+		if space != "" && store.name.Space != space {
+			continue
+		}
+
+		for _, ext := range store.extensions {
+			if attr, ok := ext.(*UnknownAttr); ok {
+				attrs = append(attrs, attr)
+			}
+		}
+	}
+
+	return attrs
+}
+
+// Categorizer is implemented by the extensions holding categories of their
+// parent, e.g. dc:subject or media:keywords
+type Categorizer interface {
+	Categories() []string
+}
+
+// Categories returns the categories held by the extensions of s implementing
+// Categorizer. Extensions are grouped by name.
+func (s *Store) Categories() []string {
+	var categories []string
+
+	for _, store := range s.stores {
+		for _, ext := range store.extensions {
+			if c, ok := ext.(Categorizer); ok {
+				categories = append(categories, c.Categories()...)
+			}
+		}
+	}
+
+	return categories
+}
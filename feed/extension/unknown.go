@@ -0,0 +1,196 @@
+package extension
+
+import (
+	"encoding/xml"
+	"strings"
+
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// Node is a generic DOM node holding an extension element for which no
+// constructor has been registered. Nodes are only built when the Manager
+// captures unknown extensions (see Manager.CaptureUnknown).
+//
+// Element and attribute names are lowercased by the walker, so a serialized
+// Node may differ in case from the original document. Its children and
+// character data are kept in document order, so that mixed content is written
+// back out as read.
+type Node struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr
+	Content []NodeContent
+
+	started bool
+	Parent  xmlutils.Visitor
+}
+
+// NodeContent is an item of the content of a Node: either a child element or
+// character data
+type NodeContent struct {
+	Child *Node
+	Text  string
+}
+
+func NewNode() *Node {
+	return &Node{}
+}
+
+func newNodeElement() Element {
+	return NewNode()
+}
+
+func (n *Node) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if !n.started {
+		n.started = true
+		n.XMLName = el.Name
+
+		for _, attr := range el.Attr {
+			if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+				continue
+			}
+			n.Attrs = append(n.Attrs, attr)
+		}
+
+		return n, nil
+	}
+
+	child := NewNode()
+	child.Parent = n
+	n.Content = append(n.Content, NodeContent{Child: child})
+
+	return child.ProcessStartElement(el)
+}
+
+func (n *Node) ProcessEndElement(el xml.EndElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	return n.Parent, nil
+}
+
+func (n *Node) ProcessCharData(el xml.CharData) (xmlutils.Visitor, xmlutils.ParserError) {
+	if last := len(n.Content) - 1; last >= 0 && n.Content[last].Child == nil {
+		n.Content[last].Text += string(el)
+	} else {
+		n.Content = append(n.Content, NodeContent{Text: string(el)})
+	}
+	return n, nil
+}
+
+func (n *Node) Name() xml.Name {
+	return n.XMLName
+}
+
+func (n *Node) String() string {
+	return strings.TrimSpace(n.Text())
+}
+
+// Text returns the character data of the node, its children excepted
+func (n *Node) Text() string {
+	text := ""
+	for _, c := range n.Content {
+		if c.Child == nil {
+			text += c.Text
+		}
+	}
+	return text
+}
+
+// Children returns the child elements of the node
+func (n *Node) Children() []*Node {
+	var children []*Node
+	for _, c := range n.Content {
+		if c.Child != nil {
+			children = append(children, c.Child)
+		}
+	}
+	return children
+}
+
+func (n *Node) SetParent(p xmlutils.Visitor) {
+	n.Parent = p
+}
+
+func (n *Node) Validate() xmlutils.ParserError {
+	return nil
+}
+
+// Attr returns the value of the attribute name
+func (n *Node) Attr(name xml.Name) (string, bool) {
+	for _, attr := range n.Attrs {
+		if attr.Name == name {
+			return attr.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Child returns the first child named name
+func (n *Node) Child(name xml.Name) (*Node, bool) {
+	for _, child := range n.Children() {
+		if child.XMLName == name {
+			return child, true
+		}
+	}
+
+	return nil, false
+}
+
+// ChildrenNS returns the children which belong to namespace space
+func (n *Node) ChildrenNS(space string) []*Node {
+	var children []*Node
+
+	for _, child := range n.Children() {
+		if child.XMLName.Space == space {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+// MarshalXML writes the node back out, start element excepted: the node name
+// is always used
+func (n *Node) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: n.XMLName, Attr: n.Attrs}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, c := range n.Content {
+		var err error
+		if c.Child != nil {
+			err = c.Child.MarshalXML(e, xml.StartElement{})
+		} else {
+			err = e.EncodeToken(xml.CharData(c.Text))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// UnknownAttr holds an extension attribute for which no constructor has been
+// registered
+type UnknownAttr struct {
+	BasicAttr
+}
+
+func newUnknownAttr(name xml.Name) Attr {
+	return &UnknownAttr{NewBasicAttr(name, nil)}
+}
+
+func (u *UnknownAttr) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: u.Name(), Value: u.Content}, nil
+}
+
+func isForeignAttr(name xml.Name) bool {
+	switch name.Space {
+	case "", "xmlns", xmlutils.XML_NS:
+		return false
+	}
+
+	return true
+}
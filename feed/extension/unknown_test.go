@@ -0,0 +1,161 @@
+package extension
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/jloup/utils"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+type testParent struct {
+	Extension VisitorExtension
+	depth     xmlutils.DepthWatcher
+}
+
+func newTestParent(manager Manager) *testParent {
+	return &testParent{Extension: InitExtension("parent", manager), depth: xmlutils.NewDepthWatcher()}
+}
+
+func (p *testParent) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if p.depth.IsRoot() {
+		for _, attr := range el.Attr {
+			p.Extension.ProcessAttr(attr, p)
+		}
+	}
+
+	if el.Name.Space != "" {
+		return p.Extension.ProcessElement(el, p)
+	}
+
+	p.depth.Down()
+	return p, nil
+}
+
+func (p *testParent) ProcessEndElement(el xml.EndElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if p.depth.Up() == xmlutils.RootLevel {
+		error := utils.NewErrorAggregator()
+		p.Extension.Validate(&error)
+
+		return p, error.ErrorObject()
+	}
+
+	return p, nil
+}
+
+func (p *testParent) ProcessCharData(el xml.CharData) (xmlutils.Visitor, xmlutils.ParserError) {
+	return p, nil
+}
+
+const testUnknownXML = `
+<parent xmlns:media="http://search.yahoo.com/mrss/" xmlns:foo="http://example.org/foo" foo:flag="on" plain="1">
+  <media:group>
+    <media:title>a title</media:title>
+    <media:thumbnail url="http://example.org/t.jpg" width="120"/>
+  </media:group>
+  <foo:bar>baz</foo:bar>
+  <foo:mixed>Hello <foo:b>big</foo:b> world<!-- c --> !</foo:mixed>
+  <child>text</child>
+</parent>`
+
+func walkTestParent(t *testing.T, manager Manager) *testParent {
+	p := newTestParent(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(testUnknownXML), p, &checker, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return p
+}
+
+func TestUnknownSkippedByDefault(t *testing.T) {
+	p := walkTestParent(t, Manager{})
+
+	if nodes := p.Extension.Store.Unknown(""); len(nodes) != 0 {
+		t.Errorf("no unknown element should be captured, got %v", len(nodes))
+	}
+
+	if attrs := p.Extension.Store.UnknownAttrs(""); len(attrs) != 0 {
+		t.Errorf("no unknown attribute should be captured, got %v", len(attrs))
+	}
+}
+
+func TestUnknownCaptured(t *testing.T) {
+	manager := Manager{}
+	manager.CaptureUnknown(true)
+
+	p := walkTestParent(t, manager)
+
+	if nodes := p.Extension.Store.Unknown(""); len(nodes) != 3 {
+		t.Fatalf("3 unknown elements should be captured, got %v", len(nodes))
+	}
+
+	nodes := p.Extension.Store.Unknown("http://search.yahoo.com/mrss/")
+	if len(nodes) != 1 {
+		t.Fatalf("1 media element should be captured, got %v", len(nodes))
+	}
+
+	group := nodes[0]
+	if group.Name().Local != "group" {
+		t.Errorf("captured element should be 'group', got '%s'", group.Name().Local)
+	}
+
+	if len(group.Children()) != 2 {
+		t.Fatalf("group should have 2 children, got %v", len(group.Children()))
+	}
+
+	title, ok := group.Child(xml.Name{Space: "http://search.yahoo.com/mrss/", Local: "title"})
+	if !ok || title.String() != "a title" {
+		t.Errorf("media:title is invalid '%s' (expected) vs '%s'", "a title", title.String())
+	}
+
+	thumbnail := group.ChildrenNS("http://search.yahoo.com/mrss/")[1]
+	if width, _ := thumbnail.Attr(xml.Name{Local: "width"}); width != "120" {
+		t.Errorf("media:thumbnail width is invalid '%s' (expected) vs '%s'", "120", width)
+	}
+
+	attrs := p.Extension.Store.UnknownAttrs("http://example.org/foo")
+	if len(attrs) != 1 || attrs[0].String() != "on" {
+		t.Errorf("foo:flag should be captured with value 'on'")
+	}
+
+	if attrs := p.Extension.Store.UnknownAttrs(""); len(attrs) != 1 {
+		t.Errorf("only namespaced attributes should be captured, got %v", len(attrs))
+	}
+}
+
+func TestUnknownMarshal(t *testing.T) {
+	manager := Manager{}
+	manager.CaptureUnknown(true)
+
+	p := walkTestParent(t, manager)
+
+	b := bytes.Buffer{}
+	if err := xml.NewEncoder(&b).Encode(p.Extension.Store.Unknown("http://example.org/foo")[0]); err != nil {
+		t.Fatalf("cannot encode node: %s", err)
+	}
+
+	expected := `<bar xmlns="http://example.org/foo">baz</bar>`
+	if b.String() != expected {
+		t.Errorf("encoded node is invalid '%s' (expected) vs '%s'", expected, b.String())
+	}
+
+	// mixed content keeps its order
+	mixed := p.Extension.Store.Unknown("http://example.org/foo")[1]
+	if text := mixed.String(); text != "Hello  world !" {
+		t.Errorf("mixed node text is invalid '%s' (expected) vs '%s'", "Hello  world !", text)
+	}
+
+	b.Reset()
+	if err := xml.NewEncoder(&b).Encode(mixed); err != nil {
+		t.Fatalf("cannot encode node: %s", err)
+	}
+
+	expected = `<mixed xmlns="http://example.org/foo">Hello <b xmlns="http://example.org/foo">big</b> world !</mixed>`
+	if b.String() != expected {
+		t.Errorf("encoded node is invalid '%s' (expected) vs '%s'", expected, b.String())
+	}
+}
@@ -0,0 +1,167 @@
+// Package identity computes stable identities of feed entries so that entries
+// can be recognized from one fetch to the other.
+//
+// The identity of an entry is taken from its guid (RSS) or id (Atom). As guid
+// is optional and some feeds change their ids on every fetch, it falls back to
+// the entry link, then its title and date, then a hash of its title and
+// content. The strategy used is reported along with the key.
+package identity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/rss"
+)
+
+// Strategy tells which fields an identity has been computed from
+type Strategy string
+
+const (
+	Id          Strategy = "id"
+	Link        Strategy = "link"
+	TitleDate   Strategy = "title+date"
+	ContentHash Strategy = "content"
+	// None is used when the entry has no usable field at all
+	None Strategy = "none"
+)
+
+// Fingerprint is the identity of an entry. Keys computed with different
+// strategies never collide.
+type Fingerprint struct {
+	Key      string
+	Strategy Strategy
+}
+
+// Entry gathers the fields an identity can be computed from
+type Entry struct {
+	Id      string
+	Link    string
+	Title   string
+	Date    time.Time
+	Content string
+}
+
+// FromAtomEntry uses id, the first alternate link, title, updated (published
+// when there is no updated, as in feed.BasicEntryBlock.Date) and content
+// (summary when there is no content)
+func FromAtomEntry(e *atom.Entry) Entry {
+	entry := Entry{
+		Id:      e.Id.String(),
+		Title:   e.Title.String(),
+		Date:    e.Updated.Time,
+		Content: e.Content.String(),
+	}
+
+	if entry.Date.IsZero() {
+		entry.Date = e.Published.Time
+	}
+
+	if entry.Content == "" {
+		entry.Content = e.Summary.String()
+	}
+
+	for _, link := range e.Links {
+		if link.Rel.String() == "alternate" {
+			entry.Link = link.Href.String()
+			break
+		}
+	}
+
+	return entry
+}
+
+// FromRssItem uses guid, link, title, pubDate and description
+func FromRssItem(i *rss.Item) Entry {
+	return Entry{
+		Id:      i.Guid.Content.String(),
+		Link:    i.Link.String(),
+		Title:   i.Title.String(),
+		Date:    i.PubDate.Time,
+		Content: i.Description.String(),
+	}
+}
+
+// FromBasicEntry uses Id, Link, Title, Date and Content (Summary when there
+// is no content), so that an entry has the same identity as when computed with
+// FromAtomEntry or FromRssItem
+func FromBasicEntry(b feed.BasicEntryBlock) Entry {
+	entry := Entry{
+		Id:      b.Id,
+		Link:    b.Link,
+		Title:   b.Title,
+		Date:    b.Date,
+		Content: b.Content,
+	}
+
+	if entry.Content == "" {
+		entry.Content = b.Summary
+	}
+
+	return entry
+}
+
+// Fingerprinter tries Strategies in order and keeps the first one the entry has
+// the fields for. None is used when no strategy applies.
+type Fingerprinter struct {
+	Strategies []Strategy
+}
+
+// DefaultFingerprinter tries id, link, title+date then content hash. For a feed
+// known to change its ids between fetches, use a Fingerprinter without Id.
+var DefaultFingerprinter = Fingerprinter{Strategies: []Strategy{Id, Link, TitleDate, ContentHash}}
+
+// Compute is a shortcut to DefaultFingerprinter.Compute
+func Compute(e Entry) Fingerprint {
+	return DefaultFingerprinter.Compute(e)
+}
+
+func (f Fingerprinter) Compute(e Entry) Fingerprint {
+	for _, strategy := range f.Strategies {
+		if value, ok := e.value(strategy); ok {
+			return Fingerprint{Key: string(strategy) + ":" + value, Strategy: strategy}
+		}
+	}
+
+	return Fingerprint{Strategy: None}
+}
+
+func (e Entry) value(strategy Strategy) (string, bool) {
+	switch strategy {
+	case Id:
+		id := strings.TrimSpace(e.Id)
+		return id, id != ""
+
+	case Link:
+		link := strings.TrimSpace(e.Link)
+		return link, link != ""
+
+	case TitleDate:
+		title := normalizeText(e.Title)
+		if title == "" || e.Date.IsZero() {
+			return "", false
+		}
+		return title + "|" + e.Date.UTC().Format(time.RFC3339), true
+
+	case ContentHash:
+		title := normalizeText(e.Title)
+		content := normalizeText(e.Content)
+		if title == "" && content == "" {
+			return "", false
+		}
+		sum := sha256.Sum256([]byte(title + "\n" + content))
+		return hex.EncodeToString(sum[:]), true
+	}
+
+	return "", false
+}
+
+// normalizeText collapses white spaces and ignores case so that reformatting
+// a title does not change the identity
+func normalizeText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,189 @@
+package identity
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func parseAtomFeed(t *testing.T, s string) *atom.Feed {
+	f := atom.NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom">`+s+`</feed>`), f, &checker, 0); err != nil {
+		t.Fatalf("cannot parse feed: %s", err)
+	}
+
+	return f
+}
+
+func parseRssChannel(t *testing.T, s string) *rss.Channel {
+	c := rss.NewChannel()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(`<channel>`+s+`</channel>`), c, &checker, 0); err != nil {
+		t.Fatalf("cannot parse channel: %s", err)
+	}
+
+	return c
+}
+
+var testDate = time.Date(2024, time.January, 6, 10, 0, 0, 0, time.UTC)
+
+func TestCompute(t *testing.T) {
+	var testdata = []struct {
+		Entry            Entry
+		Fingerprinter    Fingerprinter
+		ExpectedStrategy Strategy
+		ExpectedKey      string
+	}{
+		{Entry{Id: " urn:1 ", Link: "http://example.org/1"}, DefaultFingerprinter, Id, "id:urn:1"},
+		{Entry{Link: "http://example.org/1", Title: "One"}, DefaultFingerprinter, Link, "link:http://example.org/1"},
+		{Entry{Title: "  Hello\n  World ", Date: testDate}, DefaultFingerprinter, TitleDate, "title+date:hello world|2024-01-06T10:00:00Z"},
+		{Entry{Title: "Hello World", Content: "body"}, DefaultFingerprinter, ContentHash, ""},
+		{Entry{}, DefaultFingerprinter, None, ""},
+		{Entry{Id: "urn:1", Link: "http://example.org/1"}, Fingerprinter{Strategies: []Strategy{Link, Id}}, Link, "link:http://example.org/1"},
+	}
+
+	for _, test := range testdata {
+		f := test.Fingerprinter.Compute(test.Entry)
+
+		if f.Strategy != test.ExpectedStrategy {
+			t.Errorf("%+v: strategy should be '%s', got '%s'", test.Entry, test.ExpectedStrategy, f.Strategy)
+		}
+
+		if test.ExpectedKey != "" && f.Key != test.ExpectedKey {
+			t.Errorf("%+v: key should be '%s', got '%s'", test.Entry, test.ExpectedKey, f.Key)
+		}
+	}
+
+	// reformatted title and content keep the same hash
+	h1 := Compute(Entry{Title: "Hello World", Content: "some  body"})
+	h2 := Compute(Entry{Title: "hello  world", Content: "Some body\n"})
+	h3 := Compute(Entry{Title: "Hello World", Content: "other body"})
+	if h1 != h2 || h1 == h3 {
+		t.Errorf("content hash should ignore formatting only: %s %s %s", h1.Key, h2.Key, h3.Key)
+	}
+}
+
+func TestFromParsedEntries(t *testing.T) {
+	f := parseAtomFeed(t, `
+  <entry>
+    <id>urn:uuid:1225c695</id>
+    <title>Atom-Powered Robots Run Amok</title>
+    <link rel="edit" href="http://example.org/edit/1"/>
+    <link href="http://example.org/2003/12/13/atom03"/>
+    <updated>2003-12-13T18:30:02Z</updated>
+    <summary>Some text.</summary>
+  </entry>`)
+
+	e := FromAtomEntry(f.Entries[0])
+	if e.Id != "urn:uuid:1225c695" || e.Link != "http://example.org/2003/12/13/atom03" || e.Content != "Some text." {
+		t.Errorf("atom entry fields are invalid: %+v", e)
+	}
+
+	c := parseRssChannel(t, `
+  <item>
+    <title>Star City</title>
+    <link>http://liftoff.msfc.nasa.gov/news/2003/news-starcity.asp</link>
+    <pubDate>Tue, 03 Jun 2003 09:39:21 GMT</pubDate>
+  </item>`)
+
+	fingerprint := Compute(FromRssItem(c.Items[0]))
+	if fingerprint.Strategy != Link {
+		t.Errorf("rss item without guid should be identified by its link, got '%s'", fingerprint.Strategy)
+	}
+
+	basic := feed.BasicEntryBlock{Title: "Star City", Date: c.Items[0].PubDate.Time}
+	if Compute(FromBasicEntry(basic)).Strategy != TitleDate {
+		t.Errorf("basic entry without id nor link should be identified by its title and date")
+	}
+}
+
+func TestDedup(t *testing.T) {
+	store := NewMemoryStore()
+
+	first := []Entry{
+		{Id: "urn:1"},
+		{Link: "http://example.org/2"},
+		{Id: "urn:1"},
+		{},
+	}
+
+	fresh, err := Dedup(store, first)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fmt.Sprint(fresh) != "[0 1 3]" {
+		t.Errorf("fresh entries should be [0 1 3], got %v", fresh)
+	}
+
+	second := []Entry{
+		{Id: "urn:1"},
+		{Link: "http://example.org/2"},
+		{Id: "urn:3"},
+	}
+
+	fresh, err = Dedup(store, second)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fmt.Sprint(fresh) != "[2]" {
+		t.Errorf("fresh entries should be [2], got %v", fresh)
+	}
+
+	if store.Len() != 3 {
+		t.Errorf("store should hold 3 fingerprints, got %v", store.Len())
+	}
+}
+
+func TestMemoryStoreConcurrent(t *testing.T) {
+	store := NewMemoryStore()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				Dedup(store, []Entry{{Id: fmt.Sprintf("urn:%d", j)}})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if store.Len() != 100 {
+		t.Errorf("store should hold 100 fingerprints, got %v", store.Len())
+	}
+}
+
+func TestFromBasicEntryMatchesAtom(t *testing.T) {
+	f := parseAtomFeed(t, `
+  <entry><title>Star City</title><summary>summary</summary><content>content</content></entry>
+  <entry><id>urn:2</id><link href="http://example.org/2"/><title>Two</title></entry>
+  <entry><title>Three</title><published>2024-01-06T10:00:00Z</published><summary>no updated</summary></entry>`)
+
+	for i, e := range f.Entries {
+		b := feed.BasicEntryBlock{}
+		b.PopulateFromAtomEntry(e)
+
+		if basic, atom := FromBasicEntry(b), FromAtomEntry(e); !basic.Date.Equal(atom.Date) {
+			t.Errorf("entry #%d: basic date %s differs from atom date %s", i, basic.Date, atom.Date)
+		}
+
+		if basic, atom := Compute(FromBasicEntry(b)), Compute(FromAtomEntry(e)); basic != atom {
+			t.Errorf("entry #%d: basic fingerprint %+v differs from atom fingerprint %+v", i, basic, atom)
+		}
+	}
+
+	if fingerprint := Compute(FromAtomEntry(f.Entries[2])); fingerprint.Strategy != TitleDate {
+		t.Errorf("an entry with a title and a published date should be fingerprinted by TitleDate, got %+v", fingerprint)
+	}
+}
@@ -0,0 +1,85 @@
+package identity
+
+import (
+	"sync"
+)
+
+// Store records the fingerprints of the entries already seen. Implementations
+// backed by a database may return errors; MemoryStore never does.
+type Store interface {
+	// Has reports whether f has been recorded
+	Has(f Fingerprint) (bool, error)
+	// Add records f
+	Add(f Fingerprint) error
+}
+
+// MemoryStore is a Store kept in memory. It is safe for concurrent use.
+type MemoryStore struct {
+	mutex sync.RWMutex
+	keys  map[string]Strategy
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{keys: make(map[string]Strategy)}
+}
+
+func (m *MemoryStore) Has(f Fingerprint) (bool, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	_, ok := m.keys[f.Key]
+	return ok, nil
+}
+
+func (m *MemoryStore) Add(f Fingerprint) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.keys[f.Key] = f.Strategy
+	return nil
+}
+
+// Len returns the number of fingerprints recorded
+func (m *MemoryStore) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return len(m.keys)
+}
+
+// Dedup returns the indexes of the entries which are not in store, and records
+// them. An entry repeated within entries is reported once. Entries with the
+// None strategy cannot be recognized and are always reported.
+func (f Fingerprinter) Dedup(store Store, entries []Entry) ([]int, error) {
+	var fresh []int
+
+	for i, entry := range entries {
+		fingerprint := f.Compute(entry)
+
+		if fingerprint.Strategy == None {
+			fresh = append(fresh, i)
+			continue
+		}
+
+		seen, err := store.Has(fingerprint)
+		if err != nil {
+			return fresh, err
+		}
+
+		if seen {
+			continue
+		}
+
+		if err := store.Add(fingerprint); err != nil {
+			return fresh, err
+		}
+		fresh = append(fresh, i)
+	}
+
+	return fresh, nil
+}
+
+// Dedup is a shortcut to DefaultFingerprinter.Dedup
+func Dedup(store Store, entries []Entry) ([]int, error) {
+	return DefaultFingerprinter.Dedup(store, entries)
+}
@@ -0,0 +1,142 @@
+// Package paging walks through the pages and archives of a feed (RFC 5005,
+// Feed Paging and Archiving), e.g. to back-fill the history of a feed.
+//
+// Paging links are the atom:link of Atom feeds, and of RSS channels carrying
+// them in the Atom namespace. The fh:complete and fh:archive markers are read
+// as well. Feeds must be parsed with AddToManager registered in the extension
+// Manager for RSS links and markers to be found.
+package paging
+
+import (
+	"encoding/xml"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/atomlink"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// NS is the namespace of the fh:complete and fh:archive markers
+const NS = "http://purl.org/syndication/history/1.0"
+
+// element names are lowercased by the parser, namespace included
+var (
+	_complete = xml.Name{Space: NS, Local: "complete"}
+	_archive  = xml.Name{Space: NS, Local: "archive"}
+)
+
+// AddToManager registers fh:complete and fh:archive on Atom feeds and RSS
+// channels, and atom:link on RSS channels (see package atomlink)
+func AddToManager(manager *extension.Manager) {
+	atomlink.AddToManager(manager)
+
+	manager.AddElementExtension("feed", _complete, newAtomMarker, xmlutils.UniqueValidator(atom.AttributeDuplicated))
+	manager.AddElementExtension("feed", _archive, newAtomMarker, xmlutils.UniqueValidator(atom.AttributeDuplicated))
+	manager.AddElementExtension("channel", _complete, newRssMarker, xmlutils.UniqueValidator(rss.AttributeDuplicated))
+	manager.AddElementExtension("channel", _archive, newRssMarker, xmlutils.UniqueValidator(rss.AttributeDuplicated))
+}
+
+func newAtomMarker() extension.Element {
+	m := atom.NewBasicElement(nil)
+
+	m.Content = xmlutils.NewElement("", "", xmlutils.Nop)
+
+	return m
+}
+
+func newRssMarker() extension.Element {
+	return rss.NewBasicElement()
+}
+
+// Links are the paging links and markers of a feed document. Hrefs are kept
+// as written in the document.
+type Links struct {
+	Self     string
+	First    string
+	Last     string
+	Next     string
+	Previous string
+	// Current is the subscription document of an archive
+	Current     string
+	NextArchive string
+	PrevArchive string
+	// Complete is set by fh:complete: the document holds every entry of the
+	// feed, there is no page or archive to fetch
+	Complete bool
+	// Archive is set by fh:archive: the document is an archive, whose entries
+	// will not change
+	Archive bool
+}
+
+func fromAtomLinks(links atom.Links) Links {
+	href := func(rel string) string {
+		if link := links.First(rel); link != nil {
+			return link.Href.String()
+		}
+		return ""
+	}
+
+	l := Links{
+		Self:        href("self"),
+		First:       href("first"),
+		Last:        href("last"),
+		Next:        href("next"),
+		Previous:    href("previous"),
+		Current:     href("current"),
+		NextArchive: href("next-archive"),
+		PrevArchive: href("prev-archive"),
+	}
+
+	if l.Previous == "" {
+		l.Previous = href("prev")
+	}
+
+	return l
+}
+
+// FromAtomFeed reads the paging links and markers of f
+func FromAtomFeed(f *atom.Feed) Links {
+	l := fromAtomLinks(f.Links)
+	l.Complete = hasElement(&f.Extension.Store, _complete)
+	l.Archive = hasElement(&f.Extension.Store, _archive)
+
+	return l
+}
+
+// FromRssChannel reads the paging links (atom:link) and markers of c
+func FromRssChannel(c *rss.Channel) Links {
+	l := fromAtomLinks(atomlink.GetLinks(&c.Extension.Store))
+	l.Complete = hasElement(&c.Extension.Store, _complete)
+	l.Archive = hasElement(&c.Extension.Store, _archive)
+
+	return l
+}
+
+func hasElement(store *extension.Store, name xml.Name) bool {
+	_, ok := store.GetItf(name)
+	return ok
+}
+
+// Href returns the link of relation rel, e.g. "prev-archive", or ""
+func (l Links) Href(rel string) string {
+	switch atom.NormalizeRel(rel) {
+	case "self":
+		return l.Self
+	case "first":
+		return l.First
+	case "last":
+		return l.Last
+	case "next":
+		return l.Next
+	case "previous", "prev":
+		return l.Previous
+	case "current":
+		return l.Current
+	case "next-archive":
+		return l.NextArchive
+	case "prev-archive":
+		return l.PrevArchive
+	}
+	return ""
+}
@@ -0,0 +1,184 @@
+package paging
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/identity"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func atomPage(links, markers string, ids ...string) string {
+	var entries string
+	for _, id := range ids {
+		entries += fmt.Sprintf(`<entry><id>%s</id><title>%s</title><updated>2024-01-06T10:00:00Z</updated></entry>`, id, id)
+	}
+
+	return `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:fh="http://purl.org/syndication/history/1.0">
+  <title>t</title><id>tag:example.org,2024:feed</id><updated>2024-01-06T10:00:00Z</updated>` + links + markers + entries + `</feed>`
+}
+
+func rssPage(links string, guids ...string) string {
+	var items string
+	for _, guid := range guids {
+		items += fmt.Sprintf(`<item><guid>%s</guid><title>%s</title></item>`, guid, guid)
+	}
+
+	return `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom"><channel><title>t</title><link>http://example.org/</link><description>d</description>` + links + items + `</channel></rss>`
+}
+
+// newServer serves pages by path, and counts requests
+func newServer(pages map[string]string) (*httptest.Server, map[string]int) {
+	hits := make(map[string]int)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.URL.RequestURI()]++
+
+		page, ok := pages[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		fmt.Fprint(w, page)
+	})), hits
+}
+
+func ids(t *testing.T, start string, options Options) ([]string, Result) {
+	entries, result, err := Collect(context.Background(), start, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, entry.Id)
+	}
+
+	return ids, result
+}
+
+func TestLinks(t *testing.T) {
+	server, _ := newServer(map[string]string{
+		"/atom": atomPage(`<link rel="self" href="/atom"/><link rel="current" href="/current"/><link rel="prev" href="/prev"/>
+  <link rel="http://www.iana.org/assignments/relation/next-archive" href="/next"/><link rel="prev-archive" href="/old"/>`, `<fh:archive/>`),
+		"/rss": rssPage(`<atom:link rel="first" href="/first"/><atom:link rel="last" href="/last"/>`),
+	})
+	defer server.Close()
+
+	var testdata = []struct {
+		Path     string
+		Expected Links
+	}{
+		{"/atom", Links{Self: "/atom", Current: "/current", Previous: "/prev", NextArchive: "/next", PrevArchive: "/old", Archive: true}},
+		{"/rss", Links{First: "/first", Last: "/last"}},
+	}
+
+	for _, test := range testdata {
+		options := Options{}
+		options.fill()
+
+		page, err := fetch(context.Background(), server.URL+test.Path, options)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(page.Links, test.Expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.Path, test.Expected, page.Links)
+		}
+	}
+}
+
+func TestWalkArchives(t *testing.T) {
+	server, hits := newServer(map[string]string{
+		"/feed":      atomPage(`<link rel="prev-archive" href="/archive/2"/>`, ``, "e5", "e4"),
+		"/archive/2": atomPage(`<link rel="current" href="/feed"/><link rel="prev-archive" href="1"/>`, `<fh:archive/>`, "e4", "e3"),
+		"/archive/1": atomPage(`<link rel="current" href="/feed"/><link rel="next-archive" href="/archive/2"/>`, `<fh:archive/>`, "e2", "e1"),
+	})
+	defer server.Close()
+
+	store := identity.NewMemoryStore()
+	actual, result := ids(t, server.URL+"/feed", Options{Store: store})
+
+	if expected := []string{"e5", "e4", "e3", "e2", "e1"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected entries %v, got %v", expected, actual)
+	}
+
+	if result.Stop != StopEnd || len(result.Pages) != 3 || hits["/archive/1"] != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	// entries seen by a previous walk are skipped
+	if actual, _ := ids(t, server.URL+"/feed", Options{Store: store}); len(actual) != 0 {
+		t.Errorf("expected no new entry, got %v", actual)
+	}
+}
+
+func TestWalkStops(t *testing.T) {
+	server, _ := newServer(map[string]string{
+		"/complete":   atomPage(`<link rel="next" href="/page/2"/>`, `<fh:complete/>`, "c1"),
+		"/page/1":     rssPage(`<atom:link rel="next" href="2"/>`, "p1"),
+		"/page/2":     rssPage(`<atom:link rel="next" href="1#top"/>`, "p2"),
+		"/broken":     atomPage(`<link rel="next" href="/missing"/>`, ``, "b1"),
+		"/paged?p=1":  atomPage(`<link rel="next" href="?p=2"/>`, ``, "q1"),
+		"/paged?p=2":  atomPage(`<link rel="next" href="?p=3"/>`, ``, "q2"),
+		"/paged?p=3":  atomPage(``, ``, "q3"),
+		"/page/empty": rssPage(``),
+	})
+	defer server.Close()
+
+	var testdata = []struct {
+		Path     string
+		Options  Options
+		Expected []string
+		Stop     Stop
+		Pages    int
+	}{
+		{"/complete", Options{}, []string{"c1"}, StopComplete, 1},
+		{"/page/1", Options{}, []string{"p1", "p2"}, StopCycle, 2},
+		{"/paged?p=1", Options{MaxPages: 2}, []string{"q1", "q2"}, StopMaxPages, 2},
+		{"/paged?p=1", Options{Follow: []string{"prev-archive"}}, []string{"q1"}, StopEnd, 1},
+		{"/page/empty", Options{}, nil, StopEnd, 1},
+	}
+
+	for _, test := range testdata {
+		actual, result := ids(t, server.URL+test.Path, test.Options)
+
+		if !reflect.DeepEqual(actual, test.Expected) || result.Stop != test.Stop || len(result.Pages) != test.Pages {
+			t.Errorf("%s: expected %v (%s, %d pages), got %v (%s, %v)", test.Path, test.Expected, test.Stop, test.Pages, actual, result.Stop, result.Pages)
+		}
+	}
+
+	_, result, err := Collect(context.Background(), server.URL+"/broken", Options{})
+	if err == nil || !strings.Contains(err.Error(), "404") || len(result.Pages) != 1 {
+		t.Errorf("expected a fetch error on the second page, got %v (%+v)", err, result)
+	}
+}
+
+func TestOptionsKeepManager(t *testing.T) {
+	custom := xml.Name{Space: "http://example.org/ext", Local: "custom"}
+	manager := extension.Manager{}
+	manager.AddElementExtension("feed", custom, func() extension.Element { return atom.NewBasicElement(nil) }, xmlutils.UniqueValidator(atom.AttributeDuplicated))
+
+	options := Options{}
+	options.ParseOptions.ExtensionManager = manager
+	options.fill()
+
+	repo := options.ParseOptions.ExtensionManager.GetRepo("feed")
+	if repo.GetElement(custom) == nil || repo.GetElement(_complete) == nil {
+		t.Errorf("the extensions of the caller and of the package should both be registered")
+	}
+
+	if repo := manager.GetRepo("feed"); repo.GetElement(_complete) != nil {
+		t.Errorf("the manager of the caller should not be modified")
+	}
+}
@@ -0,0 +1,255 @@
+package paging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/identity"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// Page is a feed document fetched by Walk
+type Page struct {
+	feed.BasicFeed
+	// URL the page has been fetched from
+	URL   string
+	Links Links
+}
+
+func (p *Page) PopulateFromAtomFeed(f *atom.Feed) {
+	p.BasicFeed.PopulateFromAtomFeed(f)
+	p.Links = FromAtomFeed(f)
+}
+
+func (p *Page) PopulateFromRssChannel(c *rss.Channel) {
+	p.BasicFeed.PopulateFromRssChannel(c)
+	p.Links = FromRssChannel(c)
+}
+
+// Fetcher retrieves the document at url, along with its Content-Type when
+// known. The body is closed by the caller.
+type Fetcher func(ctx context.Context, url string) (body io.ReadCloser, contentType string, err error)
+
+// HTTPFetcher fetches documents with client, http.DefaultClient when nil.
+// Responses whose status is not 2xx are errors.
+func HTTPFetcher(client *http.Client) Fetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(ctx context.Context, url string) (io.ReadCloser, string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, "", err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			resp.Body.Close()
+			return nil, "", fmt.Errorf("GET %s: %s", url, resp.Status)
+		}
+
+		return resp.Body, resp.Header.Get("Content-Type"), nil
+	}
+}
+
+// Options is passed to Walk
+type Options struct {
+	// Fetcher defaults to HTTPFetcher(nil)
+	Fetcher Fetcher
+	// ParseOptions are used to parse every page, AddToManager being registered
+	// on a copy of their extension Manager. When ErrorFlags is nil, no error is
+	// checked.
+	ParseOptions feed.ParseOptions
+	// Follow lists the relations leading from a page to the next one, the
+	// first one present in the page being used. Defaults to "prev-archive"
+	// (archived feeds) then "next" (paged feeds), which lead to older entries.
+	Follow []string
+	// MaxPages bounds the number of pages fetched, 0 means no limit
+	MaxPages int
+	// Fingerprinter identifies entries, defaults to
+	// identity.DefaultFingerprinter
+	Fingerprinter *identity.Fingerprinter
+	// Store records the entries already seen, e.g. by a previous back-fill.
+	// Defaults to a new identity.MemoryStore.
+	Store identity.Store
+}
+
+// DefaultFollow are the relations followed when Options.Follow is empty
+var DefaultFollow = []string{"prev-archive", "next"}
+
+func (o *Options) fill() {
+	if o.Fetcher == nil {
+		o.Fetcher = HTTPFetcher(nil)
+	}
+
+	manager := o.ParseOptions.ExtensionManager.Clone()
+	AddToManager(&manager)
+	o.ParseOptions.ExtensionManager = manager
+
+	if o.ParseOptions.ErrorFlags == nil {
+		errorFlags := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+		o.ParseOptions.ErrorFlags = &errorFlags
+	}
+
+	if len(o.Follow) == 0 {
+		o.Follow = DefaultFollow
+	}
+
+	if o.Fingerprinter == nil {
+		o.Fingerprinter = &identity.DefaultFingerprinter
+	}
+
+	if o.Store == nil {
+		o.Store = identity.NewMemoryStore()
+	}
+}
+
+// Stop tells why a walk ended
+type Stop string
+
+const (
+	// StopEnd is used when the last page links to no other page
+	StopEnd Stop = "end"
+	// StopComplete is used when a page is a complete feed (fh:complete),
+	// whose paging links are ignored
+	StopComplete Stop = "complete"
+	// StopCycle is used when a page links to a page already fetched
+	StopCycle Stop = "cycle"
+	// StopMaxPages is used when Options.MaxPages pages have been fetched
+	StopMaxPages Stop = "max pages"
+)
+
+// Result summarizes a walk
+type Result struct {
+	// Pages are the URLs fetched and parsed, in walk order
+	Pages []string
+	Stop  Stop
+	// Cycle is the URL linked to again, when Stop is StopCycle
+	Cycle string
+}
+
+// Walk fetches the document at start, then the pages it links to (see
+// Options.Follow) until the history of the feed is exhausted. fn is called
+// for each entry not seen before, page after page, in document order: when an
+// entry is repeated in several pages, the most recent page wins as the walk
+// goes from newer to older pages. An error returned by fn stops the walk.
+//
+// A page linking to a page already fetched ends the walk with StopCycle. Fetch
+// and parse errors are returned along with the Result of the pages fetched so
+// far.
+func Walk(ctx context.Context, start string, options Options, fn func(p *Page, entry feed.BasicEntryBlock) error) (Result, error) {
+	options.fill()
+
+	result := Result{}
+	visited := make(map[string]bool)
+	next := start
+
+	for {
+		u, err := url.Parse(next)
+		if err != nil {
+			return result, fmt.Errorf("paging: invalid page URL %s: %w", next, err)
+		}
+		u.Fragment = ""
+
+		if visited[u.String()] {
+			result.Stop = StopCycle
+			result.Cycle = u.String()
+			return result, nil
+		}
+
+		if options.MaxPages > 0 && len(result.Pages) == options.MaxPages {
+			result.Stop = StopMaxPages
+			return result, nil
+		}
+
+		visited[u.String()] = true
+
+		page, err := fetch(ctx, u.String(), options)
+		if err != nil {
+			return result, err
+		}
+		result.Pages = append(result.Pages, u.String())
+
+		var entries []identity.Entry
+		for _, entry := range page.Entries {
+			entries = append(entries, identity.FromBasicEntry(entry))
+		}
+
+		fresh, err := options.Fingerprinter.Dedup(options.Store, entries)
+		if err != nil {
+			return result, err
+		}
+
+		for _, i := range fresh {
+			if err := fn(page, page.Entries[i]); err != nil {
+				return result, err
+			}
+		}
+
+		if page.Links.Complete {
+			result.Stop = StopComplete
+			return result, nil
+		}
+
+		href := ""
+		for _, rel := range options.Follow {
+			if href = page.Links.Href(rel); href != "" {
+				break
+			}
+		}
+
+		if href == "" {
+			result.Stop = StopEnd
+			return result, nil
+		}
+
+		ref, err := url.Parse(href)
+		if err != nil {
+			return result, fmt.Errorf("paging: invalid link %s in %s: %w", href, page.URL, err)
+		}
+		next = u.ResolveReference(ref).String()
+	}
+}
+
+// Collect is Walk, returning the entries
+func Collect(ctx context.Context, start string, options Options) ([]feed.BasicEntryBlock, Result, error) {
+	var entries []feed.BasicEntryBlock
+
+	result, err := Walk(ctx, start, options, func(p *Page, entry feed.BasicEntryBlock) error {
+		entries = append(entries, entry)
+		return nil
+	})
+
+	return entries, result, err
+}
+
+func fetch(ctx context.Context, url string, options Options) (*Page, error) {
+	body, contentType, err := options.Fetcher(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("paging: cannot fetch %s: %w", url, err)
+	}
+	defer body.Close()
+
+	parseOptions := options.ParseOptions
+	if contentType != "" {
+		parseOptions.ContentType = contentType
+	}
+
+	page := &Page{URL: url}
+	if err := feed.ParseCustomContext(ctx, body, page, parseOptions); err != nil {
+		return nil, fmt.Errorf("paging: cannot parse %s: %w", url, err)
+	}
+
+	return page, nil
+}
@@ -2,7 +2,9 @@
 package feed
 
 import (
+	"context"
 	"io"
+	"io/ioutil"
 
 	"github.com/jloup/utils"
 	"github.com/jloup/xml/feed/extension"
@@ -11,7 +13,9 @@ import (
 
 var NoFeedFound = utils.InitFlag(&xmlutils.ErrorFlagCounter, "NoFeedFound")
 
-// ParseOptions is passed to Parse functions to customize their behaviors
+// ParseOptions is passed to Parse functions to customize their behaviors.
+// Parsing only reads ExtensionManager and ErrorFlags: the same ParseOptions can
+// be used by concurrent parses once extensions and error flags are set up.
 type ParseOptions struct {
 	// extensions to use while parsing
 	ExtensionManager extension.Manager
@@ -19,40 +23,92 @@ type ParseOptions struct {
 	ErrorFlags xmlutils.FlagChecker
 	// number of retry to recover from bad input
 	XMLTokenErrorRetry int
+	// resource limits of the parsing, no limit by default
+	Limits xmlutils.Limits
+	// Content-Type of the document (e.g. from HTTP headers) whose charset is
+	// used to detect its encoding, see xmlutils.DetectEncoding
+	ContentType string
 }
 
 // DefaultOptions set options in order to have:
 // - no specification checking
 // - no extension
+// - no resource limit
 var DefaultOptions ParseOptions
 
 func init() {
 	errorFlags := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
 	DefaultOptions = ParseOptions{
-		extension.Manager{},
-		&errorFlags,
-		0,
+		ExtensionManager: extension.Manager{},
+		ErrorFlags:       &errorFlags,
 	}
 }
 
 // ParseCustom parse bytes from a io.Reader into a UserFeed object
 func ParseCustom(r io.Reader, feed UserFeed, options ParseOptions) error {
-	w := newWrapperExt(options.ExtensionManager)
+	return ParseCustomContext(context.Background(), r, feed, options)
+}
+
+// ParseCustomContext is ParseCustom, aborting with a xmlutils.Canceled error
+// once ctx is done
+func ParseCustomContext(ctx context.Context, r io.Reader, feed UserFeed, options ParseOptions) error {
+	_, _, err := parseDocument(ctx, r, feed, options, false)
+	return err
+}
+
+// ParseCustomEncoding is ParseCustomContext, also returning how the encoding
+// of the document has been detected
+func ParseCustomEncoding(ctx context.Context, r io.Reader, feed UserFeed, options ParseOptions) (xmlutils.EncodingReport, error) {
+	report, _, err := parseDocument(ctx, r, feed, options, false)
+	return report, err
+}
 
-	err := xmlutils.Walk(r, w, options.ErrorFlags, options.XMLTokenErrorRetry)
+// ParseCustomRecover is ParseCustomContext, parsing the document once repaired
+// by xmlutils.Recover. The repairs made are returned, even when parsing fails.
+// Their offsets are in the document converted to UTF-8.
+func ParseCustomRecover(ctx context.Context, r io.Reader, feed UserFeed, options ParseOptions) ([]xmlutils.Repair, error) {
+	_, repairs, err := parseDocument(ctx, r, feed, options, true)
+	return repairs, err
+}
+
+func parseDocument(ctx context.Context, r io.Reader, feed UserFeed, options ParseOptions, repair bool) (xmlutils.EncodingReport, []xmlutils.Repair, error) {
+	var report xmlutils.EncodingReport
+	var repairs []xmlutils.Repair
+
+	if max := options.Limits.MaxInputSize; max > 0 {
+		r = io.LimitReader(r, max+1)
+	}
 
+	b, err := ioutil.ReadAll(r)
 	if err != nil {
-		return err
+		return report, repairs, xmlutils.NewError(xmlutils.IOError, "Cannot read content")
+	}
+
+	if err := options.Limits.CheckInputSize(int64(len(b))); err != nil {
+		return report, repairs, err
+	}
+
+	b, report = xmlutils.ToUTF8(b, options.ContentType)
+
+	if repair {
+		b, repairs = xmlutils.Recover(b)
+	}
+
+	w := newWrapperExt(options.ExtensionManager)
+
+	// the input size is checked above: once decoded and repaired, the document
+	// may be larger, WalkUTF8 does not check it again
+	if err := xmlutils.WalkUTF8(ctx, b, w, options.ErrorFlags, options.XMLTokenErrorRetry, options.Limits); err != nil {
+		return report, repairs, err
 	}
 
 	if w.AtomFeed == nil && w.RssChannel == nil && w.AtomEntry == nil {
-		return xmlutils.NewError(NoFeedFound, "no feed has been found")
+		return report, repairs, xmlutils.NewError(NoFeedFound, "no feed has been found")
 	}
 
 	w.Populate(feed)
 
-	return nil
-
+	return report, repairs, nil
 }
 
 // Parse is a subset a ParseCustom with BasicFeed passed as UserFeed
@@ -61,3 +117,11 @@ func Parse(r io.Reader, options ParseOptions) (BasicFeed, error) {
 
 	return feed, ParseCustom(r, &feed, options)
 }
+
+// ParseContext is Parse, aborting with a xmlutils.Canceled error once ctx is
+// done
+func ParseContext(ctx context.Context, r io.Reader, options ParseOptions) (BasicFeed, error) {
+	feed := BasicFeed{}
+
+	return feed, ParseCustomContext(ctx, r, &feed, options)
+}
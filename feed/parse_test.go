@@ -0,0 +1,250 @@
+package feed_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jloup/xml/feed"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func testContentFeed(content string) string {
+	return `<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>t</title>
+  <entry>
+    <title>e</title>
+    ` + content + `
+  </entry>
+</feed>`
+}
+
+func TestParseContentLimit(t *testing.T) {
+	large := strings.Repeat("a", 1024)
+
+	tests := []struct {
+		content string
+		limited bool
+	}{
+		{`<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml">short</div></content>`, false},
+		{`<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml">` + large + `</div></content>`, true},
+		{`<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml">` + strings.Repeat("<p>a</p>", 200) + `</div></content>`, true},
+		{`<content type="application/xml"><data>` + large + `</data></content>`, true},
+		{`<summary type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml">` + large + `</div></summary>`, true},
+	}
+
+	options := feed.DefaultOptions
+	options.Limits.MaxContentLength = 512
+
+	for i, test := range tests {
+		_, err := feed.Parse(strings.NewReader(testContentFeed(test.content)), options)
+
+		if !test.limited {
+			if err != nil {
+				t.Errorf("test #%d: unexpected error %s", i, err)
+			}
+			continue
+		}
+
+		perr, ok := err.(xmlutils.ParserError)
+		if !ok || !perr.Flag().Cmp(xmlutils.ContentLimitExceeded) {
+			t.Errorf("test #%d: expecting ContentLimitExceeded, got %v", i, err)
+		}
+	}
+}
+
+func TestParseInputLimit(t *testing.T) {
+	doc := testContentFeed("")
+
+	options := feed.DefaultOptions
+	options.Limits.MaxInputSize = int64(len(doc)) - 1
+
+	_, err := feed.Parse(strings.NewReader(doc), options)
+
+	perr, ok := err.(xmlutils.ParserError)
+	if !ok || !perr.Flag().Cmp(xmlutils.InputTooLarge) || !strings.HasSuffix(perr.Error(), "input size exceeds limit of "+fmt.Sprint(options.Limits.MaxInputSize)) {
+		t.Errorf("expecting InputTooLarge, got %v", err)
+	}
+
+	options.Limits.MaxInputSize = int64(len(doc))
+	if _, err := feed.Parse(strings.NewReader(doc), options); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+}
+
+func TestParseContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := feed.ParseContext(ctx, strings.NewReader(testContentFeed("")), feed.DefaultOptions)
+
+	perr, ok := err.(xmlutils.ParserError)
+	if !ok || !perr.Flag().Cmp(xmlutils.Canceled) {
+		t.Errorf("expecting Canceled, got %v", err)
+	}
+}
+
+func TestParseCustomRecover(t *testing.T) {
+	doc := `<rss version="2.0"><channel>
+  <title>News &nbsp;&amp; views</title>
+  <link>http://example.org/?a=1&b=2</link>
+  <item><title>First</title><description>a<br>b</description></item>
+  <item><title>Second</title></p></item>
+</channel>`
+
+	if _, err := feed.Parse(strings.NewReader(doc), feed.DefaultOptions); err == nil {
+		t.Fatalf("document should not be parsed without recovery")
+	}
+
+	f := feed.BasicFeed{}
+	repairs, err := feed.ParseCustomRecover(context.Background(), strings.NewReader(doc), &f, feed.DefaultOptions)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if f.Title != "News \u00a0& views" || f.Id != "http://example.org/?a=1&b=2" || len(f.Entries) != 2 || f.Entries[1].Title != "Second" {
+		t.Errorf("unexpected feed %+v", f)
+	}
+
+	var kinds []string
+	for _, r := range repairs {
+		kinds = append(kinds, r.Kind.String())
+	}
+
+	expected := "HTMLEntity StrayAmpersand AutoClosedTag StrayEndTag UnclosedTag"
+	if strings.Join(kinds, " ") != expected {
+		t.Errorf("expected repairs %s, got %v", expected, repairs)
+	}
+}
+
+func TestParseCustomEncoding(t *testing.T) {
+	doc := "<?xml version=\"1.0\" encoding=\"utf-8\"?><rss version=\"2.0\"><channel><title>Caf\xe9</title></channel></rss>"
+
+	options := feed.DefaultOptions
+	options.ContentType = "application/rss+xml; charset=ISO-8859-1"
+
+	f := feed.BasicFeed{}
+	report, err := feed.ParseCustomEncoding(context.Background(), strings.NewReader(doc), &f, options)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if f.Title != "Café" {
+		t.Errorf("expected title 'Café', got '%s'", f.Title)
+	}
+
+	if report.Encoding != "windows-1252" || report.Source != xmlutils.ContentTypeSource || report.Declared != "utf-8" {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestBasicEntryEnclosures(t *testing.T) {
+	docs := []string{
+		`<rss version="2.0"><channel><title>t</title><item><title>e</title>
+  <enclosure url="http://example.org/a.mp3" length="1200" type="audio/mpeg"/>
+  <enclosure url="http://example.org/a.ogg" length="" type="audio/ogg"/>
+</item></channel></rss>`,
+		testContentFeed(`<link rel="enclosure" href="http://example.org/a.mp3" length="1200" type="audio/mpeg"/>
+    <link rel="alternate" href="http://example.org/"/>
+    <link rel="enclosure" href="http://example.org/a.ogg" type="audio/ogg"/>`),
+	}
+
+	expected := []feed.BasicEnclosure{
+		{Url: "http://example.org/a.mp3", Type: "audio/mpeg", Length: 1200},
+		{Url: "http://example.org/a.ogg", Type: "audio/ogg", Length: 0},
+	}
+
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+	options := feed.DefaultOptions
+	options.ErrorFlags = &checker
+
+	for i, doc := range docs {
+		f := feed.BasicFeed{}
+		if err := feed.ParseCustom(strings.NewReader(doc), &f, options); err != nil {
+			t.Fatalf("test #%d: unexpected error %s", i, err)
+		}
+
+		if len(f.Entries) != 1 {
+			t.Fatalf("test #%d: expected 1 entry, got %d", i, len(f.Entries))
+		}
+
+		if !reflect.DeepEqual(f.Entries[0].Enclosures, expected) {
+			t.Errorf("test #%d: expected enclosures %v, got %v", i, expected, f.Entries[0].Enclosures)
+		}
+	}
+}
+
+func TestBasicFeedFields(t *testing.T) {
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+	options := feed.DefaultOptions
+	options.ErrorFlags = &checker
+
+	atomDoc := `<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en">
+  <title>t</title>
+  <subtitle>sub</subtitle>
+  <icon>http://example.org/favicon.ico</icon>
+  <link href="http://example.org/"/>
+  <link rel="alternate" type="application/json" href="http://example.org/feed.json"/>
+  <link rel="self" href="http://example.org/feed.atom"/>
+  <entry>
+    <title>e</title>
+    <published>2024-01-05T10:00:00Z</published>
+    <summary>short</summary>
+    <content>long</content>
+    <link href="http://example.org/1"/>
+    <link rel="alternate" href="http://example.org/1.json"/>
+    <link rel="replies" href="http://example.org/1/comments"/>
+    <source><id>urn:source</id><title>Elsewhere</title><link href="http://elsewhere.org/"/></source>
+  </entry>
+</feed>`
+
+	f := feed.BasicFeed{}
+	if err := feed.ParseCustom(strings.NewReader(atomDoc), &f, options); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if f.Description != "sub" || f.Language != "en" || f.Icon != "http://example.org/favicon.ico" || f.Link != "http://example.org/" || len(f.Links.ByRel("alternate")) != 2 || f.Links.First("self") != "http://example.org/feed.atom" {
+		t.Errorf("unexpected atom feed %+v", f.BasicFeedBlock)
+	}
+
+	e := f.Entries[0]
+	published := time.Date(2024, time.January, 5, 10, 0, 0, 0, time.UTC)
+	if e.Link != "http://example.org/1" || e.Summary != "short" || e.Content != "long" || e.Comments != "http://example.org/1/comments" || !e.Date.Equal(published) || !e.Published.Equal(published) {
+		t.Errorf("unexpected atom entry %+v", e)
+	}
+
+	if e.Source.Id != "urn:source" || e.Source.Title != "Elsewhere" || e.Source.Link != "http://elsewhere.org/" {
+		t.Errorf("unexpected atom source %+v", e.Source)
+	}
+
+	rssDoc := `<rss version="2.0"><channel>
+  <title>t</title>
+  <link>http://example.org/</link>
+  <description>desc</description>
+  <language>fr</language>
+  <pubDate>Fri, 05 Jan 2024 10:00:00 GMT</pubDate>
+  <item>
+    <title>e</title>
+    <description>body</description>
+    <comments>http://example.org/1#comments</comments>
+    <source url="http://elsewhere.org/rss">Elsewhere</source>
+  </item>
+</channel></rss>`
+
+	f = feed.BasicFeed{}
+	if err := feed.ParseCustom(strings.NewReader(rssDoc), &f, options); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if f.Description != "desc" || f.Language != "fr" || f.Link != "http://example.org/" || !f.Date.Equal(published) || !f.Published.Equal(published) {
+		t.Errorf("unexpected rss feed %+v", f.BasicFeedBlock)
+	}
+
+	e = f.Entries[0]
+	if e.Summary != "body" || e.Content != "body" || e.Comments != "http://example.org/1#comments" || e.Source.Title != "Elsewhere" || e.Source.Self != "http://elsewhere.org/rss" {
+		t.Errorf("unexpected rss entry %+v", e)
+	}
+}
@@ -0,0 +1,190 @@
+// Package person normalizes the authors of feed entries across formats.
+//
+// Atom persons are structured (name, uri, email). RSS author and
+// managingEditor hold an email address, usually followed by a name in
+// parentheses ("jane@example.com (Jane Doe)"), and dc:creator holds a name:
+// Parse splits such strings.
+//
+// Authors are inherited as defined by Atom (RFC 4287, section 4.2.1): an entry
+// without author takes the authors of its atom:source, then the ones of its
+// feed. RSS items are handled alike, author and dc:creator at item level then
+// managingEditor at channel level. dc:creator is only read when the dc
+// extension is registered in the extension Manager.
+package person
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/dc"
+)
+
+// Person is an author of an entry or a feed
+type Person struct {
+	Name  string
+	Email string
+	Uri   string
+}
+
+// IsZero reports whether p has no field set
+func (p Person) IsZero() bool {
+	return p.Name == "" && p.Email == "" && p.Uri == ""
+}
+
+// String formats p the RSS way: "email (name)", or whichever is set
+func (p Person) String() string {
+	switch {
+	case p.Email != "" && p.Name != "":
+		return p.Email + " (" + p.Name + ")"
+	case p.Email != "":
+		return p.Email
+	}
+	return p.Name
+}
+
+var (
+	emailName = regexp.MustCompile(`^(\S+@\S+)\s*\((.*)\)$`)
+	nameEmail = regexp.MustCompile(`^(.*?)\s*[<(]\s*(\S+@[^\s>)]+)\s*[>)]$`)
+	email     = regexp.MustCompile(`^[^\s@]+@\S+$`)
+)
+
+// Parse splits a free-form person, as found in RSS author, managingEditor and
+// dc:creator. The following forms are recognized, any other string is a name:
+//
+// - jane@example.com (Jane Doe)
+// - Jane Doe <jane@example.com>, Jane Doe (jane@example.com)
+// - jane@example.com, mailto:jane@example.com
+func Parse(s string) Person {
+	s = strings.Join(strings.Fields(s), " ")
+
+	if m := emailName.FindStringSubmatch(s); m != nil {
+		return Person{Name: cleanName(m[2]), Email: cleanEmail(m[1])}
+	}
+
+	if m := nameEmail.FindStringSubmatch(s); m != nil {
+		return Person{Name: cleanName(m[1]), Email: cleanEmail(m[2])}
+	}
+
+	if e := cleanEmail(s); email.MatchString(e) {
+		return Person{Email: e}
+	}
+
+	return Person{Name: cleanName(s)}
+}
+
+func cleanEmail(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > len("mailto:") && strings.EqualFold(s[:len("mailto:")], "mailto:") {
+		s = s[len("mailto:"):]
+	}
+	return s
+}
+
+func cleanName(s string) string {
+	return strings.TrimSpace(strings.Trim(strings.TrimSpace(s), `"'`))
+}
+
+// Merge removes empty persons and merges the ones which are the same person:
+// same email, or same name when one of them has no email. Fields missing in the
+// first occurrence are taken from the following ones. Order is kept.
+func Merge(persons ...Person) []Person {
+	var merged []Person
+
+	for _, p := range persons {
+		if p.IsZero() {
+			continue
+		}
+
+		i := 0
+		for ; i < len(merged) && !merged[i].same(p); i++ {
+		}
+
+		if i == len(merged) {
+			merged = append(merged, p)
+			continue
+		}
+
+		if merged[i].Name == "" {
+			merged[i].Name = p.Name
+		}
+		if merged[i].Email == "" {
+			merged[i].Email = p.Email
+		}
+		if merged[i].Uri == "" {
+			merged[i].Uri = p.Uri
+		}
+	}
+
+	return merged
+}
+
+func (p Person) same(o Person) bool {
+	if p.Email != "" && o.Email != "" {
+		return strings.EqualFold(p.Email, o.Email)
+	}
+	return p.Name != "" && strings.EqualFold(p.Name, o.Name)
+}
+
+// Inherit returns the first level which has persons, from the most specific
+// (e.g. entry) to the least specific (e.g. feed)
+func Inherit(levels ...[]Person) []Person {
+	for _, persons := range levels {
+		if len(persons) > 0 {
+			return persons
+		}
+	}
+	return nil
+}
+
+// FromAtomPerson converts an Atom person
+func FromAtomPerson(p *atom.Person) Person {
+	return Person{
+		Name:  strings.TrimSpace(p.Name.String()),
+		Email: cleanEmail(p.Email.String()),
+		Uri:   strings.TrimSpace(p.Uri.String()),
+	}
+}
+
+// FromAtomPersons converts and merges Atom persons
+func FromAtomPersons(persons []*atom.Person) []Person {
+	var p []Person
+	for _, person := range persons {
+		p = append(p, FromAtomPerson(person))
+	}
+	return Merge(p...)
+}
+
+// AtomEntryAuthors returns the authors of e, inherited from its atom:source then
+// from its feed (when e has been parsed within one) when it has none
+func AtomEntryAuthors(e *atom.Entry) []Person {
+	var feedAuthors []Person
+	if f, ok := e.Parent.(*atom.Feed); ok {
+		feedAuthors = FromAtomPersons(f.Authors)
+	}
+
+	return Inherit(FromAtomPersons(e.Authors), FromAtomPersons(e.Source.Authors), feedAuthors)
+}
+
+// RssChannelAuthors returns the managingEditor of c
+func RssChannelAuthors(c *rss.Channel) []Person {
+	return Merge(Parse(c.ManagingEditor.String()))
+}
+
+// RssItemAuthors returns the author and dc:creator of i, merged, or the
+// managingEditor of its channel (when i has been parsed within one) when it
+// has none
+func RssItemAuthors(i *rss.Item) []Person {
+	persons := []Person{Parse(i.Author.String())}
+	if creator, ok := dc.GetCreator(i); ok {
+		persons = append(persons, Parse(creator.String()))
+	}
+
+	var channelAuthors []Person
+	if c, ok := i.Parent.(*rss.Channel); ok {
+		channelAuthors = RssChannelAuthors(c)
+	}
+
+	return Inherit(Merge(persons...), channelAuthors)
+}
@@ -0,0 +1,115 @@
+package person
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/dc"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func TestParse(t *testing.T) {
+	var testdata = []struct {
+		In       string
+		Expected Person
+	}{
+		{"", Person{}},
+		{"jane@example.com (Jane Doe)", Person{Name: "Jane Doe", Email: "jane@example.com"}},
+		{" jane@example.com  ( Jane  Doe ) ", Person{Name: "Jane Doe", Email: "jane@example.com"}},
+		{`"Jane Doe" <jane@example.com>`, Person{Name: "Jane Doe", Email: "jane@example.com"}},
+		{"Jane Doe (jane@example.com)", Person{Name: "Jane Doe", Email: "jane@example.com"}},
+		{"<jane@example.com>", Person{Email: "jane@example.com"}},
+		{"mailto:jane@example.com", Person{Email: "jane@example.com"}},
+		{"Jane Doe", Person{Name: "Jane Doe"}},
+		{"Jane Doe (Editor)", Person{Name: "Jane Doe (Editor)"}},
+	}
+
+	for _, test := range testdata {
+		if p := Parse(test.In); p != test.Expected {
+			t.Errorf("'%s': expected %+v, got %+v", test.In, test.Expected, p)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge(
+		Person{Email: "jane@example.com"},
+		Person{},
+		Person{Name: "John Roe"},
+		Person{Name: "Jane Doe", Email: "Jane@Example.com"},
+		Person{Name: "john roe", Uri: "http://example.org/john"},
+		Person{Name: "John Roe", Email: "john@example.com"},
+	)
+
+	expected := []Person{
+		{Name: "Jane Doe", Email: "jane@example.com"},
+		{Name: "John Roe", Email: "john@example.com", Uri: "http://example.org/john"},
+	}
+
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %+v, got %+v", expected, merged)
+	}
+}
+
+func TestAtomEntryAuthors(t *testing.T) {
+	f := atom.NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	doc := `<feed xmlns="http://www.w3.org/2005/Atom">
+  <author><name>John Doe</name><email>mailto:john@example.org</email></author>
+  <entry><author><name>Jane Roe</name></author></entry>
+  <entry><source><author><name>Someone Else</name></author></source></entry>
+  <entry></entry>
+</feed>`
+
+	if err := xmlutils.Walk(strings.NewReader(doc), f, &checker, 0); err != nil {
+		t.Fatalf("cannot parse feed: %s", err)
+	}
+
+	expected := [][]Person{
+		{{Name: "Jane Roe"}},
+		{{Name: "Someone Else"}},
+		{{Name: "John Doe", Email: "john@example.org"}},
+	}
+
+	for i, e := range f.Entries {
+		if authors := AtomEntryAuthors(e); !reflect.DeepEqual(authors, expected[i]) {
+			t.Errorf("entry #%d: expected %+v, got %+v", i, expected[i], authors)
+		}
+	}
+}
+
+func TestRssItemAuthors(t *testing.T) {
+	manager := extension.Manager{}
+	dc.AddToManager(&manager)
+
+	c := rss.NewChannelExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	doc := `<channel xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <managingEditor>editor@example.org (The Editor)</managingEditor>
+  <item><author>jane@example.org (Jane Doe)</author><dc:creator>Jane Doe</dc:creator></item>
+  <item><dc:creator>John Roe</dc:creator></item>
+  <item></item>
+</channel>`
+
+	if err := xmlutils.Walk(strings.NewReader(doc), c, &checker, 0); err != nil {
+		t.Fatalf("cannot parse channel: %s", err)
+	}
+
+	expected := [][]Person{
+		{{Name: "Jane Doe", Email: "jane@example.org"}},
+		{{Name: "John Roe"}},
+		{{Name: "The Editor", Email: "editor@example.org"}},
+	}
+
+	for i, item := range c.Items {
+		if authors := RssItemAuthors(item); !reflect.DeepEqual(authors, expected[i]) {
+			t.Errorf("item #%d: expected %+v, got %+v", i, expected[i], authors)
+		}
+	}
+}
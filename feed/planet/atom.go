@@ -0,0 +1,169 @@
+package planet
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/jloup/xml/feed"
+)
+
+type atomFeed struct {
+	XMLName  xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	Id       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Subtitle string       `xml:"subtitle,omitempty"`
+	Updated  string       `xml:"updated"`
+	Links    []atomLink   `xml:"link"`
+	Authors  []atomPerson `xml:"author"`
+	Entries  []atomEntry  `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+}
+
+type atomPerson struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email,omitempty"`
+	Uri   string `xml:"uri,omitempty"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	Id         string           `xml:"id"`
+	Title      string           `xml:"title"`
+	Updated    string           `xml:"updated"`
+	Published  string           `xml:"published,omitempty"`
+	Links      []atomLink       `xml:"link"`
+	Authors    []atomPerson     `xml:"author"`
+	Categories []atomCategory   `xml:"category"`
+	Summary    *atomText        `xml:"summary"`
+	Content    *atomText        `xml:"content"`
+	Source     *feed.AtomSource `xml:"source"`
+}
+
+func atomDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func atomLinks(alternate, self string) []atomLink {
+	var links []atomLink
+	if alternate != "" {
+		links = append(links, atomLink{Rel: "alternate", Href: alternate})
+	}
+	if self != "" {
+		links = append(links, atomLink{Rel: "self", Href: self})
+	}
+	return links
+}
+
+func atomAuthors(persons []Person) []atomPerson {
+	var authors []atomPerson
+	for _, p := range persons {
+		if p.Name != "" {
+			authors = append(authors, atomPerson{Name: p.Name, Email: p.Email, Uri: p.Uri})
+		}
+	}
+	return authors
+}
+
+func (p *Planet) atomEntry(e Entry, updated time.Time) atomEntry {
+	entry := atomEntry{
+		Id:      e.Id,
+		Title:   e.Title,
+		Updated: atomDate(e.date()),
+		Links:   atomLinks(e.Link, ""),
+		Authors: atomAuthors(e.Authors),
+	}
+
+	if e.date().IsZero() {
+		entry.Updated = atomDate(updated)
+	}
+
+	if !e.Published.IsZero() {
+		entry.Published = atomDate(e.Published)
+	}
+
+	for _, category := range e.Categories {
+		entry.Categories = append(entry.Categories, atomCategory{Term: category})
+	}
+
+	if e.Summary != "" {
+		entry.Summary = &atomText{Type: "text", Value: e.Summary}
+		if e.SummaryType == "html" {
+			entry.Summary.Type = "html"
+		}
+	}
+
+	// atom requires either a summary or a readable content
+	if e.Content != "" || entry.Summary == nil {
+		entry.Content = &atomText{Type: "text", Value: e.Content}
+		if e.ContentType == "html" {
+			entry.Content.Type = "html"
+		}
+	}
+
+	if e.Source.Id != p.Options.Id {
+		date := e.date()
+		if date.IsZero() {
+			date = updated
+		}
+
+		source := feed.NewAtomSource(e.Source, date)
+		entry.Source = &source
+	}
+
+	return entry
+}
+
+// WriteAtom writes the combined feed as an Atom document. Options.Title and
+// Options.Self are required.
+func (p *Planet) WriteAtom(w io.Writer) error {
+	if err := p.Options.require("Atom", "Title", p.Options.Title, "Self", p.Options.Self); err != nil {
+		return err
+	}
+
+	entries := p.Entries()
+	updated := p.updated(entries)
+
+	f := atomFeed{
+		Id:       p.Options.Id,
+		Title:    p.Options.Title,
+		Subtitle: p.Options.Subtitle,
+		Updated:  atomDate(updated),
+		Links:    atomLinks(p.Options.Link, p.Options.Self),
+		Authors:  atomAuthors([]Person{p.Options.Author}),
+	}
+
+	for _, e := range entries {
+		f.Entries = append(f.Entries, p.atomEntry(e, updated))
+	}
+
+	return encode(w, f)
+}
+
+func encode(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,137 @@
+package planet
+
+import (
+	"time"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/identity"
+	"github.com/jloup/xml/feed/person"
+	"github.com/jloup/xml/feed/rss"
+)
+
+// Person is an author of an entry or a feed
+type Person = person.Person
+
+// Source describes the feed an entry has been taken from
+type Source = feed.BasicSource
+
+// Entry is an entry normalized from either format
+type Entry struct {
+	Id      string
+	Title   string
+	Link    string
+	Summary string
+	// SummaryType is either "text" or "html"
+	SummaryType string
+	Content     string
+	// ContentType is either "text" or "html"
+	ContentType string
+	Updated     time.Time
+	Published   time.Time
+	// Authors are the entry authors, inherited from its source when it has none
+	Authors    []Person
+	Categories []string
+	Source     Source
+
+	Fingerprint identity.Fingerprint
+}
+
+// date returns the date entries are sorted by
+func (e *Entry) date() time.Time {
+	if e.Updated.IsZero() {
+		return e.Published
+	}
+	return e.Updated
+}
+
+func findLink(links []*atom.Link, rel string) string {
+	for _, link := range links {
+		if link.Rel.String() == rel {
+			return link.Href.String()
+		}
+	}
+	return ""
+}
+
+func newAtomEntry(f *atom.Feed, e *atom.Entry, fingerprinter identity.Fingerprinter) Entry {
+	entry := Entry{
+		Id:        e.Id.String(),
+		Title:     e.Title.String(),
+		Link:      findLink(e.Links, "alternate"),
+		Summary:   e.Summary.String(),
+		Updated:   e.Updated.Time,
+		Published: e.Published.Time,
+		Authors:   person.Inherit(person.AtomEntryAuthors(e), person.FromAtomPersons(f.Authors)),
+		Source:    feed.SourceFromAtomFeed(f),
+	}
+
+	// an entry already copied from another feed keeps its original source
+	if source := feed.SourceFromAtomSource(e.Source); !source.IsZero() {
+		entry.Source = source
+	}
+
+	entry.SummaryType = "text"
+	if e.Summary.Type == "html" || e.Summary.Type == "xhtml" {
+		entry.SummaryType = "html"
+	}
+
+	switch e.Content.Type.String() {
+	case "text":
+		entry.Content, entry.ContentType = e.Content.String(), "text"
+	case "html", "xhtml":
+		entry.Content, entry.ContentType = e.Content.String(), "html"
+	}
+
+	for _, category := range e.Categories {
+		entry.Categories = append(entry.Categories, category.Term.String())
+	}
+
+	entry.Fingerprint = fingerprinter.Compute(identity.FromAtomEntry(e))
+	entry.Id = feed.AbsoluteIRI(entry.Fingerprint.Key+entry.Id, entry.Id, entry.Link)
+
+	return entry
+}
+
+// sourceFromRssChannel identifies c by its link, as channels have no id
+func sourceFromRssChannel(c *rss.Channel) Source {
+	source := feed.SourceFromRssChannel(c)
+	source.Id = c.Link.String()
+	return source
+}
+
+func channelDate(c *rss.Channel) time.Time {
+	if c.LastBuildDate.Time.IsZero() {
+		return c.PubDate.Time
+	}
+	return c.LastBuildDate.Time
+}
+
+func newRssItem(c *rss.Channel, i *rss.Item, fingerprinter identity.Fingerprinter) Entry {
+	entry := Entry{
+		Id:          i.Guid.Content.String(),
+		Title:       i.Title.String(),
+		Link:        i.Link.String(),
+		Content:     i.Description.String(),
+		ContentType: "html",
+		Updated:     i.PubDate.Time,
+		Published:   i.PubDate.Time,
+		Source:      sourceFromRssChannel(c),
+	}
+
+	// an item already copied from another channel keeps its original source
+	if i.Source.Url.String() != "" {
+		entry.Source = Source{Id: i.Source.Url.String(), Title: i.Source.Content.String(), Self: i.Source.Url.String()}
+	}
+
+	entry.Authors = person.Inherit(person.RssItemAuthors(i), person.RssChannelAuthors(c))
+
+	for _, category := range i.Categories {
+		entry.Categories = append(entry.Categories, category.Content.String())
+	}
+
+	entry.Fingerprint = fingerprinter.Compute(identity.FromRssItem(i))
+	entry.Id = feed.AbsoluteIRI(entry.Fingerprint.Key+entry.Id, entry.Id, entry.Link)
+
+	return entry
+}
@@ -0,0 +1,194 @@
+// Package planet merges several parsed feeds, of either format, into a single
+// "planet" feed.
+//
+// Entries are normalized, deduplicated by identity (see package identity) and
+// sorted from the most recent. Every entry keeps a description of the feed it
+// comes from, written as atom:source in Atom output and as source in RSS
+// output. Both outputs pass the validators of the atom and rss packages:
+// WriteAtom requires Options.Title and Options.Self, WriteRss Options.Title
+// and Options.Link, and they return ErrMissingOption otherwise.
+package planet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/identity"
+	"github.com/jloup/xml/feed/rss"
+)
+
+// Options describes the combined feed and the entries it keeps
+type Options struct {
+	Title string
+	// Subtitle is the atom subtitle and the rss description; defaults to Title
+	Subtitle string
+	// Link is the web page of the planet
+	Link string
+	// Self is the URL the combined feed is published at
+	Self string
+	// Id of the combined Atom feed; defaults to Self, then Link
+	Id string
+	// Author is the planet author, inherited by entries which have none;
+	// defaults to Title
+	Author Person
+	// Updated is the date of the combined feed; defaults to the date of its
+	// most recent entry
+	Updated time.Time
+
+	// Limit is the maximum number of entries kept, PerFeed the maximum number
+	// of entries kept from a single source. Zero means no limit.
+	Limit   int
+	PerFeed int
+	// Entries older than Since are dropped
+	Since time.Time
+
+	// Fingerprinter identifies entries; defaults to identity.DefaultFingerprinter
+	Fingerprinter identity.Fingerprinter
+}
+
+// ErrMissingOption is returned by WriteAtom and WriteRss when an option
+// required by their output is empty
+var ErrMissingOption = errors.New("planet: missing option")
+
+// require checks that the options named in pairs with their values are set
+func (o *Options) require(output string, options ...string) error {
+	for i := 0; i < len(options); i += 2 {
+		if options[i+1] == "" {
+			return fmt.Errorf("%w: %s output requires %s", ErrMissingOption, output, options[i])
+		}
+	}
+	return nil
+}
+
+// Planet collects entries from several feeds
+type Planet struct {
+	Options Options
+
+	entries []Entry
+}
+
+func New(options Options) *Planet {
+	if len(options.Fingerprinter.Strategies) == 0 {
+		options.Fingerprinter = identity.DefaultFingerprinter
+	}
+
+	if options.Subtitle == "" {
+		options.Subtitle = options.Title
+	}
+
+	if options.Id == "" {
+		options.Id = options.Self
+	}
+	if options.Id == "" {
+		options.Id = options.Link
+	}
+
+	if options.Author.Name == "" {
+		options.Author.Name = options.Title
+	}
+
+	return &Planet{Options: options}
+}
+
+// AddAtomFeed adds the entries of f
+func (p *Planet) AddAtomFeed(f *atom.Feed) {
+	for _, e := range f.Entries {
+		p.entries = append(p.entries, newAtomEntry(f, e, p.Options.Fingerprinter))
+	}
+}
+
+// AddRssChannel adds the items of c
+func (p *Planet) AddRssChannel(c *rss.Channel) {
+	for _, i := range c.Items {
+		p.entries = append(p.entries, newRssItem(c, i, p.Options.Fingerprinter))
+	}
+}
+
+// Entries returns the entries of the combined feed, most recent first.
+//
+// Of several entries sharing an identity or a link, the most recently updated
+// is kept: the same article often has an id in an Atom feed and only a link in
+// an RSS feed.
+//
+// Since, PerFeed and Limit are then applied in that order.
+func (p *Planet) Entries() []Entry {
+	entries := make([]Entry, len(p.entries))
+	copy(entries, p.entries)
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].date().After(entries[j].date()) })
+
+	var kept []Entry
+	seen := keySet{}
+	perFeed := make(map[string]int)
+
+	for _, entry := range entries {
+		keys := entry.keys()
+		if seen.any(keys) {
+			continue
+		}
+		seen.add(keys)
+
+		if !p.Options.Since.IsZero() && entry.date().Before(p.Options.Since) {
+			continue
+		}
+
+		if p.Options.PerFeed > 0 {
+			if perFeed[entry.Source.Id] >= p.Options.PerFeed {
+				continue
+			}
+			perFeed[entry.Source.Id]++
+		}
+
+		if p.Options.Limit > 0 && len(kept) >= p.Options.Limit {
+			break
+		}
+
+		kept = append(kept, entry)
+	}
+
+	return kept
+}
+
+func (p *Planet) updated(entries []Entry) time.Time {
+	if !p.Options.Updated.IsZero() {
+		return p.Options.Updated
+	}
+
+	if len(entries) > 0 && !entries[0].date().IsZero() {
+		return entries[0].date()
+	}
+
+	return time.Now()
+}
+
+type keySet map[string]bool
+
+func (k keySet) any(keys []string) bool {
+	for _, key := range keys {
+		if k[key] {
+			return true
+		}
+	}
+	return false
+}
+
+func (k keySet) add(keys []string) {
+	for _, key := range keys {
+		k[key] = true
+	}
+}
+
+// keys identifies e for deduplication
+func (e *Entry) keys() []string {
+	var keys []string
+	if e.Fingerprint.Strategy != identity.None {
+		keys = append(keys, e.Fingerprint.Key)
+	}
+	if e.Link != "" {
+		keys = append(keys, string(identity.Link)+":"+e.Link)
+	}
+	return keys
+}
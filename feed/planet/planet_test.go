@@ -0,0 +1,313 @@
+package planet
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+const testAtomFeed = `
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Example Blog</title>
+  <id>urn:uuid:60a76c80-d399-11d9-b93C-0003939e0af6</id>
+  <updated>2024-01-06T12:00:00Z</updated>
+  <link href="http://example.org/"/>
+  <link rel="self" href="http://example.org/feed.atom"/>
+  <author><name>John Doe</name><email>john@example.org</email></author>
+  <entry>
+    <title>Atom post 1</title>
+    <id>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a</id>
+    <link href="http://example.org/1"/>
+    <updated>2024-01-06T10:00:00Z</updated>
+    <summary>First &amp; foremost</summary>
+    <category term="go"/>
+  </entry>
+  <entry>
+    <title>Atom post 2</title>
+    <id>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6b</id>
+    <link href="http://example.org/2"/>
+    <updated>2024-01-06T12:00:00Z</updated>
+    <content type="html">&lt;p&gt;Second&lt;/p&gt;</content>
+    <author><name>Jane Roe</name></author>
+  </entry>
+  <entry>
+    <title>Copied post</title>
+    <id>tag:elsewhere.org,2024:copied</id>
+    <link href="http://elsewhere.org/copied"/>
+    <updated>2024-01-06T09:00:00Z</updated>
+    <summary type="html">&lt;b&gt;copied&lt;/b&gt;</summary>
+    <source>
+      <id>tag:elsewhere.org,2024:feed</id>
+      <title>Elsewhere</title>
+      <link rel="self" href="http://elsewhere.org/feed.atom"/>
+      <author><name>Someone Else</name></author>
+    </source>
+  </entry>
+</feed>`
+
+const testRssChannel = `
+<channel>
+  <title>Liftoff News</title>
+  <link>http://liftoff.msfc.nasa.gov/</link>
+  <description>Liftoff to Space Exploration.</description>
+  <item>
+    <title>Star City</title>
+    <link>http://liftoff.msfc.nasa.gov/news/2003/news-starcity.asp</link>
+    <description>How do Americans get &lt;b&gt;ready&lt;/b&gt; to work with Russians?</description>
+    <pubDate>Sat, 06 Jan 2024 11:00:00 GMT</pubDate>
+    <guid>http://liftoff.msfc.nasa.gov/2003/06/03.html#item573</guid>
+  </item>
+  <item>
+    <description>Sky watchers in Europe, Asia, and parts of Alaska and Canada will experience a partial eclipse.</description>
+    <pubDate>Fri, 05 Jan 2024 11:00:00 GMT</pubDate>
+    <guid isPermaLink="false">item572</guid>
+  </item>
+  <item>
+    <title>Atom post 1</title>
+    <link>http://example.org/1</link>
+    <pubDate>Sat, 06 Jan 2024 08:00:00 GMT</pubDate>
+  </item>
+</channel>`
+
+func parseAtomFeed(t *testing.T, s string) *atom.Feed {
+	f := atom.NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(s), f, &checker, 0); err != nil {
+		t.Fatalf("cannot parse feed: %s", err)
+	}
+
+	return f
+}
+
+func parseRssChannel(t *testing.T, s string) *rss.Channel {
+	c := rss.NewChannel()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(s), c, &checker, 0); err != nil {
+		t.Fatalf("cannot parse channel: %s", err)
+	}
+
+	return c
+}
+
+func newTestPlanet(t *testing.T, options Options) *Planet {
+	p := New(options)
+	p.AddAtomFeed(parseAtomFeed(t, testAtomFeed))
+	p.AddRssChannel(parseRssChannel(t, testRssChannel))
+
+	return p
+}
+
+var testOptions = Options{
+	Title: "Planet Example",
+	Link:  "http://planet.example.org/",
+	Self:  "http://planet.example.org/atom.xml",
+}
+
+func titles(entries []Entry) string {
+	var s []string
+	for _, e := range entries {
+		s = append(s, e.Title)
+	}
+	return strings.Join(s, "|")
+}
+
+func TestEntries(t *testing.T) {
+	p := newTestPlanet(t, testOptions)
+	entries := p.Entries()
+
+	// "Atom post 1" is in both feeds, the most recently updated one is kept
+	expected := "Atom post 2|Star City|Atom post 1|Copied post|"
+	if titles(entries) != expected {
+		t.Fatalf("entries should be '%s', got '%s'", expected, titles(entries))
+	}
+
+	if entries[0].Source.Title != "Example Blog" || entries[0].Authors[0].Name != "Jane Roe" {
+		t.Errorf("entry source or author is invalid: %+v", entries[0])
+	}
+
+	if entries[2].Authors[0].Name != "John Doe" {
+		t.Errorf("entry without author should inherit feed authors, got %+v", entries[2].Authors)
+	}
+
+	if entries[3].Source.Title != "Elsewhere" || entries[3].Authors[0].Name != "Someone Else" {
+		t.Errorf("copied entry should keep its original source: %+v", entries[3].Source)
+	}
+
+	if entries[4].Source.Title != "Liftoff News" || !strings.HasPrefix(entries[4].Id, "urn:sha256:") {
+		t.Errorf("rss item without IRI guid should get a generated id: %+v", entries[4])
+	}
+}
+
+func TestLimits(t *testing.T) {
+	var testdata = []struct {
+		Limit    int
+		PerFeed  int
+		Since    time.Time
+		Expected string
+	}{
+		{2, 0, time.Time{}, "Atom post 2|Star City"},
+		{0, 1, time.Time{}, "Atom post 2|Star City|Copied post"},
+		{0, 0, time.Date(2024, time.January, 6, 9, 30, 0, 0, time.UTC), "Atom post 2|Star City|Atom post 1"},
+	}
+
+	for _, test := range testdata {
+		options := testOptions
+		options.Limit, options.PerFeed, options.Since = test.Limit, test.PerFeed, test.Since
+
+		if got := titles(newTestPlanet(t, options).Entries()); got != test.Expected {
+			t.Errorf("limit %v perFeed %v since %v: entries should be '%s', got '%s'", test.Limit, test.PerFeed, test.Since, test.Expected, got)
+		}
+	}
+}
+
+func TestWriteAtom(t *testing.T) {
+	var b bytes.Buffer
+	if err := newTestPlanet(t, testOptions).WriteAtom(&b); err != nil {
+		t.Fatalf("cannot write atom: %s", err)
+	}
+
+	f := atom.NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+	if err := xmlutils.Walk(bytes.NewReader(b.Bytes()), f, &checker, 0); err != nil {
+		t.Fatalf("combined atom feed is not valid: %s\n%s", err, b.String())
+	}
+
+	options := feed.DefaultOptions
+	options.ErrorFlags = &checker
+	if _, err := feed.Parse(bytes.NewReader(b.Bytes()), options); err != nil {
+		t.Fatalf("combined atom document is not valid: %s", err)
+	}
+
+	if len(f.Entries) != 5 || f.Title.String() != "Planet Example" {
+		t.Fatalf("combined atom feed is invalid:\n%s", b.String())
+	}
+
+	if f.Entries[0].Source.Title.String() != "Example Blog" || f.Entries[3].Source.Id.String() != "tag:elsewhere.org,2024:feed" {
+		t.Errorf("entries should carry their atom:source:\n%s", b.String())
+	}
+
+	if f.Entries[0].Content.String() != "<p>Second</p>" {
+		t.Errorf("html content should be kept, got '%s'", f.Entries[0].Content.String())
+	}
+
+	if summary := f.Entries[3].Summary; summary.Type != "html" || summary.String() != "<b>copied</b>" {
+		t.Errorf("html summary should be kept, got '%s' (%s)", summary.String(), summary.Type)
+	}
+	if summary := f.Entries[2].Summary; summary.Type != "text" || summary.String() != "First & foremost" {
+		t.Errorf("text summary should be kept, got '%s' (%s)", summary.String(), summary.Type)
+	}
+}
+
+func TestWriteRss(t *testing.T) {
+	var b bytes.Buffer
+	if err := newTestPlanet(t, testOptions).WriteRss(&b); err != nil {
+		t.Fatalf("cannot write rss: %s", err)
+	}
+
+	c := rss.NewChannel()
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+	if err := xmlutils.Walk(strings.NewReader(strings.Replace(strings.Replace(b.String(), `<rss version="2.0">`, "", 1), "</rss>", "", 1)), c, &checker, 0); err != nil {
+		t.Fatalf("combined rss channel is not valid: %s\n%s", err, b.String())
+	}
+
+	options := feed.DefaultOptions
+	options.ErrorFlags = &checker
+	if _, err := feed.Parse(bytes.NewReader(b.Bytes()), options); err != nil {
+		t.Fatalf("combined rss document is not valid: %s", err)
+	}
+
+	if len(c.Items) != 5 {
+		t.Fatalf("combined rss channel is invalid:\n%s", b.String())
+	}
+
+	if c.Items[0].Source.Url.String() != "http://example.org/feed.atom" || c.Items[0].Source.Content.String() != "Example Blog" {
+		t.Errorf("items should carry their source:\n%s", b.String())
+	}
+
+	if !c.Items[1].PubDate.Time.Equal(time.Date(2024, time.January, 6, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("item date is invalid: %s", c.Items[1].PubDate.Time)
+	}
+}
+
+func TestWriteMissingOptions(t *testing.T) {
+	noSelf := testOptions
+	noSelf.Self = ""
+
+	noLink := testOptions
+	noLink.Link = ""
+
+	noTitle := testOptions
+	noTitle.Title = ""
+
+	var testdata = []struct {
+		Name     string
+		Options  Options
+		AtomFail bool
+		RssFail  bool
+	}{
+		{"no self", noSelf, true, false},
+		{"no link", noLink, false, true},
+		{"no title", noTitle, true, true},
+	}
+
+	for _, test := range testdata {
+		p := newTestPlanet(t, test.Options)
+
+		var b bytes.Buffer
+		err := p.WriteAtom(&b)
+		if test.AtomFail != errors.Is(err, ErrMissingOption) {
+			t.Errorf("%s: unexpected atom error %v", test.Name, err)
+		}
+
+		if err == nil {
+			f := atom.NewFeed()
+			checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+			if err := xmlutils.Walk(bytes.NewReader(b.Bytes()), f, &checker, 0); err != nil {
+				t.Errorf("%s: combined atom feed is not valid: %s", test.Name, err)
+			}
+		}
+
+		b.Reset()
+		err = p.WriteRss(&b)
+		if test.RssFail != errors.Is(err, ErrMissingOption) {
+			t.Errorf("%s: unexpected rss error %v", test.Name, err)
+		}
+
+		if err == nil {
+			c := rss.NewChannel()
+			checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+			if err := xmlutils.Walk(strings.NewReader(strings.Replace(strings.Replace(b.String(), `<rss version="2.0">`, "", 1), "</rss>", "", 1)), c, &checker, 0); err != nil {
+				t.Errorf("%s: combined rss channel is not valid: %s", test.Name, err)
+			}
+		}
+	}
+}
+
+func TestRssItemAuthors(t *testing.T) {
+	var testdata = []struct {
+		Authors  []Person
+		Author   string
+		Creators string
+	}{
+		{[]Person{{Name: "Jane Roe", Email: "jane@example.com"}}, "jane@example.com (Jane Roe)", ""},
+		{[]Person{{Email: "jane@example.com"}}, "jane@example.com", ""},
+		{[]Person{{Name: "Jane Roe"}, {Name: "John Doe", Email: "john@example.com"}}, "john@example.com (John Doe)", "Jane Roe"},
+	}
+
+	p := New(testOptions)
+	for i, test := range testdata {
+		item := p.rssItem(Entry{Title: "t", Authors: test.Authors})
+		if item.Author != test.Author || strings.Join(item.Creator, "|") != test.Creators {
+			t.Errorf("test #%d: expected author '%s' and creators '%s', got '%s' and '%s'", i, test.Author, test.Creators, item.Author, strings.Join(item.Creator, "|"))
+		}
+	}
+}
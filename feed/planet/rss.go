@@ -0,0 +1,122 @@
+package planet
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Self          *atomLink `xml:"http://www.w3.org/2005/Atom link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssGuid struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssSource struct {
+	Url   string `xml:"url,attr"`
+	Title string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string     `xml:"title,omitempty"`
+	Link        string     `xml:"link,omitempty"`
+	Description string     `xml:"description,omitempty"`
+	Author      string     `xml:"author,omitempty"`
+	Creator     []string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string   `xml:"category"`
+	Guid        rssGuid    `xml:"guid"`
+	PubDate     string     `xml:"pubDate,omitempty"`
+	Source      *rssSource `xml:"source"`
+}
+
+func rssDate(t time.Time) string {
+	return t.UTC().Format("Mon, 02 Jan 2006 15:04:05 GMT")
+}
+
+func (p *Planet) rssItem(e Entry) rssItem {
+	item := rssItem{
+		Title:       e.Title,
+		Link:        e.Link,
+		Description: e.Content,
+		Categories:  e.Categories,
+		Guid:        rssGuid{IsPermaLink: "false", Value: e.Id},
+	}
+
+	if item.Description == "" {
+		item.Description = e.Summary
+	}
+
+	// an item must have a title or a description
+	if item.Title == "" && item.Description == "" {
+		item.Title = e.Link
+	}
+
+	if e.Id == e.Link {
+		item.Guid.IsPermaLink = "true"
+	}
+
+	if !e.date().IsZero() {
+		item.PubDate = rssDate(e.date())
+	}
+
+	// rss author holds an email address, names go to dc:creator
+	for _, author := range e.Authors {
+		if author.Email != "" && item.Author == "" {
+			item.Author = author.String()
+		} else if author.Name != "" {
+			item.Creator = append(item.Creator, author.Name)
+		}
+	}
+
+	if e.Source.Self != "" && e.Source.Self != p.Options.Self {
+		item.Source = &rssSource{Url: e.Source.Self, Title: e.Source.Title}
+	}
+
+	return item
+}
+
+// WriteRss writes the combined feed as an RSS 2.0 document. RSS source only
+// holds the URL of the original feed, so entries from feeds whose URL is not
+// known (no Atom self link) get no source. Options.Title and Options.Link are
+// required.
+func (p *Planet) WriteRss(w io.Writer) error {
+	if err := p.Options.require("RSS", "Title", p.Options.Title, "Link", p.Options.Link); err != nil {
+		return err
+	}
+
+	entries := p.Entries()
+
+	doc := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         p.Options.Title,
+			Link:          p.Options.Link,
+			Description:   p.Options.Subtitle,
+			LastBuildDate: rssDate(p.updated(entries)),
+		},
+	}
+
+	if p.Options.Self != "" {
+		doc.Channel.Self = &atomLink{Rel: "self", Href: p.Options.Self}
+	}
+
+	for _, e := range entries {
+		doc.Channel.Items = append(doc.Channel.Items, p.rssItem(e))
+	}
+
+	return encode(w, doc)
+}
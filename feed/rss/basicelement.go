@@ -44,7 +44,7 @@ func (b *BasicElement) Name() xml.Name {
 func (b *BasicElement) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if b.depth.IsRoot() {
 		b.name = el.Name
-		b.Extension = extension.InitExtension(b.name.Local, b.Extension.Manager)
+		b.Extension = extension.InitExtension(el.Path, b.Extension.Manager, "basicelement")
 
 		for _, attr := range el.Attr {
 			b.Extension.ProcessAttr(attr, b)
@@ -52,7 +52,7 @@ func (b *BasicElement) ProcessStartElement(el xmlutils.StartElement) (xmlutils.V
 	}
 
 	if b.depth.Down() == xmlutils.MaxDepthReached {
-		return b, xmlutils.NewError(LeafElementHasChild, fmt.Sprintf("'%s' shoud not have childs", b.Name))
+		return b, xmlutils.NewError(LeafElementHasChild, fmt.Sprintf("'%s' shoud not have childs", b.name.Local))
 	}
 
 	return b, nil
@@ -22,7 +22,7 @@ func NewCategory() *Category {
 	c := Category{depth: xmlutils.NewDepthWatcher()}
 
 	c.Domain = xmlutils.NewElement("domain", "", xmlutils.Nop)
-	c.Domain.SetOccurence(xmlutils.NewOccurence("domain", xmlutils.UniqueValidator(AttributeDuplicated)))
+	c.Domain.SetOccurence(xmlutils.NewOccurence("domain", isUnique))
 
 	return &c
 }
@@ -40,6 +40,7 @@ func (c *Category) reset() {
 
 func (c *Category) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if c.depth.IsRoot() {
+		c.Extension = extension.InitExtension(el.Path, c.Extension.Manager)
 		c.reset()
 		for _, attr := range el.Attr {
 			switch attr.Name.Space {
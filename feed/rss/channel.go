@@ -23,16 +23,18 @@ type Channel struct {
 	Generator      *BasicElement
 	Docs           *BasicElement
 	Cloud          *Cloud
-	Ttl            *BasicElement
+	Ttl            *Ttl
 	Image          *Image
 	Rating         *BasicElement
-	SkipHours      *BasicElement
-	SkipDays       *BasicElement
+	TextInput      *TextInput
+	SkipHours      *SkipHours
+	SkipDays       *SkipDays
 
 	Items      []*Item
 	Parent     xmlutils.Visitor
 	Extension  extension.VisitorExtension
 	depth      xmlutils.DepthWatcher
+	xmlLang    string
 	Occurences xmlutils.OccurenceCollection
 }
 
@@ -50,11 +52,12 @@ func NewChannel() *Channel {
 		Generator:      NewBasicElement(),
 		Docs:           NewBasicElement(),
 		Cloud:          NewCloud(),
-		Ttl:            NewBasicElement(),
+		Ttl:            NewTtl(),
 		Image:          NewImage(),
 		Rating:         NewBasicElement(),
-		SkipHours:      NewBasicElement(),
-		SkipDays:       NewBasicElement(),
+		TextInput:      NewTextInput(),
+		SkipHours:      NewSkipHours(),
+		SkipDays:       NewSkipDays(),
 
 		depth: xmlutils.NewDepthWatcher(),
 	}
@@ -78,11 +81,12 @@ func NewChannelExt(manager extension.Manager) *Channel {
 		Generator:      NewBasicElementExt(manager),
 		Docs:           NewBasicElementExt(manager),
 		Cloud:          NewCloudExt(manager),
-		Ttl:            NewBasicElementExt(manager),
+		Ttl:            NewTtlExt(manager),
 		Image:          NewImageExt(manager),
 		Rating:         NewBasicElementExt(manager),
-		SkipHours:      NewBasicElementExt(manager),
-		SkipDays:       NewBasicElementExt(manager),
+		TextInput:      NewTextInputExt(manager),
+		SkipHours:      NewSkipHoursExt(manager),
+		SkipDays:       NewSkipDaysExt(manager),
 
 		depth: xmlutils.NewDepthWatcher(),
 	}
@@ -97,16 +101,14 @@ func (c *Channel) init() {
 
 	c.Title.Content = xmlutils.NewElement("title", "", xmlutils.Nop)
 	c.Link.Content = xmlutils.NewElement("link", "", IsValidIRI)
-	c.Language.Content = xmlutils.NewElement("language", "", xmlutils.Nop)
+	c.Language.Content = xmlutils.NewElement("language", "", xmlutils.IsValidLanguage)
 	c.Copyright.Content = xmlutils.NewElement("copyright", "", xmlutils.Nop)
 	c.ManagingEditor.Content = xmlutils.NewElement("managingeditor", "", xmlutils.Nop)
 	c.Webmaster.Content = xmlutils.NewElement("webmaster", "", xmlutils.Nop)
 	c.Generator.Content = xmlutils.NewElement("generator", "", xmlutils.Nop)
 	c.Docs.Content = xmlutils.NewElement("docs", "", xmlutils.Nop)
-	c.Ttl.Content = xmlutils.NewElement("ttl", "", xmlutils.Nop)
+	c.Ttl.Content = xmlutils.NewElement("ttl", "", IsValidNumber)
 	c.Rating.Content = xmlutils.NewElement("rating", "", xmlutils.Nop)
-	c.SkipHours.Content = xmlutils.NewElement("skiphours", "", xmlutils.Nop)
-	c.SkipDays.Content = xmlutils.NewElement("skipdays", "", xmlutils.Nop)
 
 	c.Title.Parent = c
 	c.Link.Parent = c
@@ -123,27 +125,29 @@ func (c *Channel) init() {
 	c.Ttl.Parent = c
 	c.Image.Parent = c
 	c.Rating.Parent = c
+	c.TextInput.Parent = c
 	c.SkipHours.Parent = c
 	c.SkipDays.Parent = c
 
 	c.Occurences = xmlutils.NewOccurenceCollection(
-		xmlutils.NewOccurence("title", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)),
-		xmlutils.NewOccurence("link", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)),
-		xmlutils.NewOccurence("description", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)),
-		xmlutils.NewOccurence("language", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("copyright", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("managingeditor", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("webmaster", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("pubdate", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("lastbuilddate", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("generator", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("docs", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("cloud", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("ttl", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("image", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("rating", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("skiphours", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("skipdays", xmlutils.UniqueValidator(AttributeDuplicated)),
+		xmlutils.NewOccurence("title", existsAndIsUnique),
+		xmlutils.NewOccurence("link", existsAndIsUnique),
+		xmlutils.NewOccurence("description", existsAndIsUnique),
+		xmlutils.NewOccurence("language", isUnique),
+		xmlutils.NewOccurence("copyright", isUnique),
+		xmlutils.NewOccurence("managingeditor", isUnique),
+		xmlutils.NewOccurence("webmaster", isUnique),
+		xmlutils.NewOccurence("pubdate", isUnique),
+		xmlutils.NewOccurence("lastbuilddate", isUnique),
+		xmlutils.NewOccurence("generator", isUnique),
+		xmlutils.NewOccurence("docs", isUnique),
+		xmlutils.NewOccurence("cloud", isUnique),
+		xmlutils.NewOccurence("ttl", isUnique),
+		xmlutils.NewOccurence("image", isUnique),
+		xmlutils.NewOccurence("rating", isUnique),
+		xmlutils.NewOccurence("textinput", isUnique),
+		xmlutils.NewOccurence("skiphours", isUnique),
+		xmlutils.NewOccurence("skipdays", isUnique),
 	)
 
 }
@@ -154,7 +158,9 @@ func (c *Channel) reset() {
 
 func (c *Channel) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if c.depth.IsRoot() {
+		c.Extension = extension.InitExtension(el.Path, c.Extension.Manager)
 		c.reset()
+		c.xmlLang = el.Lang
 		for _, attr := range el.Attr {
 			c.Extension.ProcessAttr(attr, c)
 		}
@@ -173,7 +179,7 @@ func (c *Channel) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visito
 
 		case "description":
 			c.Occurences.Inc("description")
-			return c.Description, nil
+			return c.Description.start(el)
 
 		case "language":
 			c.Occurences.Inc("language")
@@ -229,6 +235,10 @@ func (c *Channel) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visito
 			c.Occurences.Inc("rating")
 			return c.Rating.ProcessStartElement(el)
 
+		case "textinput":
+			c.Occurences.Inc("textinput")
+			return c.TextInput.ProcessStartElement(el)
+
 		case "skiphours":
 			c.Occurences.Inc("skiphours")
 			return c.SkipHours.ProcessStartElement(el)
@@ -271,3 +281,17 @@ func (c *Channel) validate() xmlutils.ParserError {
 
 	return err.ErrorObject()
 }
+
+// EffectiveLang returns the language of the channel: its language element,
+// else the xml:lang in scope. It is empty when unknown.
+func (c *Channel) EffectiveLang() string {
+	if lang := c.Language.String(); lang != "" {
+		return lang
+	}
+	return c.xmlLang
+}
+
+// Direction returns the writing direction of the language of the channel
+func (c *Channel) Direction() xmlutils.Direction {
+	return xmlutils.LanguageDirection(c.EffectiveLang())
+}
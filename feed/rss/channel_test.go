@@ -2,8 +2,11 @@ package rss
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/jloup/xml/feed/extension"
 	xmlutils "github.com/jloup/xml/utils"
 )
 
@@ -21,11 +24,12 @@ func NewTestChannel(
 	generator *BasicElement,
 	docs *BasicElement,
 	cloud *Cloud,
-	ttl *BasicElement,
+	ttl *Ttl,
 	image *Image,
 	rating *BasicElement,
-	skiphours *BasicElement,
-	skipdays *BasicElement,
+	textinput *TextInput,
+	skiphours *SkipHours,
+	skipdays *SkipDays,
 	items []*Item,
 ) *Channel {
 
@@ -47,6 +51,7 @@ func NewTestChannel(
 	c.Ttl = ttl
 	c.Image = image
 	c.Rating = rating
+	c.TextInput = textinput
 	c.SkipHours = skiphours
 	c.SkipDays = skipdays
 	c.Items = items
@@ -131,12 +136,16 @@ func testChannelValidator(actual xmlutils.Visitor, expected xmlutils.Visitor) er
 		return fmt.Errorf("Rating is invalid '%s' (expected) vs '%s'", c2.Rating.String(), c1.Rating.String())
 	}
 
-	if c1.SkipHours.String() != c2.SkipHours.String() {
-		return fmt.Errorf("SkipHours is invalid '%s' (expected) vs '%s'", c2.SkipHours.String(), c1.SkipHours.String())
+	if err := testTextInputValidator(c1.TextInput, c2.TextInput); err != nil {
+		return err
+	}
+
+	if fmt.Sprint(c1.SkipHours.Hours) != fmt.Sprint(c2.SkipHours.Hours) {
+		return fmt.Errorf("SkipHours is invalid '%v' (expected) vs '%v'", c2.SkipHours.Hours, c1.SkipHours.Hours)
 	}
 
-	if c1.SkipDays.String() != c2.SkipDays.String() {
-		return fmt.Errorf("SkipDays is invalid '%s' (expected) vs '%s'", c2.SkipDays.String(), c1.SkipDays.String())
+	if fmt.Sprint(c1.SkipDays.Days) != fmt.Sprint(c2.SkipDays.Days) {
+		return fmt.Errorf("SkipDays is invalid '%v' (expected) vs '%v'", c2.SkipDays.Days, c1.SkipDays.Days)
 	}
 
 	if len(c1.Items) != len(c2.Items) {
@@ -203,11 +212,12 @@ func TestChannelBasic(t *testing.T) {
 				NewTestBasicElement("Weblog Editor 2.0"),
 				NewTestBasicElement("http://blogs.law.harvard.edu/tech/rss"),
 				NewCloud(),
-				NewBasicElement(),
+				NewTtl(),
 				NewImage(),
 				NewBasicElement(),
-				NewBasicElement(),
-				NewBasicElement(),
+				NewTextInput(),
+				NewSkipHours(),
+				NewSkipDays(),
 				nil,
 			),
 		},
@@ -232,11 +242,12 @@ func TestChannelBasic(t *testing.T) {
 				NewBasicElement(),
 				NewBasicElement(),
 				NewCloud(),
-				NewBasicElement(),
+				NewTtl(),
 				NewImage(),
 				NewBasicElement(),
-				NewBasicElement(),
-				NewBasicElement(),
+				NewTextInput(),
+				NewSkipHours(),
+				NewSkipDays(),
 				nil,
 			),
 		},
@@ -260,11 +271,12 @@ func TestChannelBasic(t *testing.T) {
 				NewBasicElement(),
 				NewBasicElement(),
 				NewCloud(),
-				NewBasicElement(),
+				NewTtl(),
 				NewImage(),
 				NewBasicElement(),
-				NewBasicElement(),
-				NewBasicElement(),
+				NewTextInput(),
+				NewSkipHours(),
+				NewSkipDays(),
 				nil,
 			),
 		}, {`
@@ -287,11 +299,12 @@ func TestChannelBasic(t *testing.T) {
 				NewBasicElement(),
 				NewBasicElement(),
 				NewCloud(),
-				NewBasicElement(),
+				NewTtl(),
 				NewImage(),
 				NewBasicElement(),
-				NewBasicElement(),
-				NewBasicElement(),
+				NewTextInput(),
+				NewSkipHours(),
+				NewSkipDays(),
 				nil,
 			),
 		},
@@ -315,11 +328,12 @@ func TestChannelBasic(t *testing.T) {
 				NewBasicElement(),
 				NewBasicElement(),
 				NewCloud(),
-				NewBasicElement(),
+				NewTtl(),
 				NewImage(),
 				NewBasicElement(),
-				NewBasicElement(),
-				NewBasicElement(),
+				NewTextInput(),
+				NewSkipHours(),
+				NewSkipDays(),
 				nil,
 			),
 		},
@@ -346,11 +360,12 @@ func TestChannelBasic(t *testing.T) {
 				NewBasicElement(),
 				NewBasicElement(),
 				NewCloud(),
-				NewBasicElement(),
+				NewTtl(),
 				NewImage(),
 				NewBasicElement(),
-				NewBasicElement(),
-				NewBasicElement(),
+				NewTextInput(),
+				NewSkipHours(),
+				NewSkipDays(),
 				[]*Item{
 					NewTestItem(
 						NewTestBasicElement("Star City"),
@@ -359,7 +374,7 @@ func TestChannelBasic(t *testing.T) {
 						NewBasicElement(),
 						nil,
 						NewBasicElement(),
-						NewEnclosure(),
+						nil,
 						NewGuid(),
 						NewDate(),
 						NewSource(),
@@ -382,3 +397,187 @@ func TestChannelBasic(t *testing.T) {
 
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestChannelExtensionSelectors(t *testing.T) {
+	ext := extension.NewDefinition("http://example.org/ext",
+		extension.Field{Name: "credit", Parents: []string{"channel/image"}},
+		extension.Field{Name: "format", Attr: true, Parents: []string{"item/description"}},
+		extension.Field{Name: "note", Attr: true, Parents: []string{"item/title"}},
+	)
+
+	manager := extension.Manager{}
+	if err := manager.AddDefinition(ext); err != nil {
+		t.Fatalf("cannot add definition: %s", err)
+	}
+
+	c := NewChannelExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+  <channel xmlns:ext="http://example.org/ext">
+    <title ext:note="channel">Me, Myself and I</title>
+    <link>http://example.org/</link>
+    <description ext:format="text">Channel description</description>
+    <image>
+      <url>http://example.org/logo.png</url>
+      <title>Me, Myself and I</title>
+      <link>http://example.org/</link>
+      <ext:credit>Jane</ext:credit>
+    </image>
+    <item>
+      <title ext:note="item">Breakfast</title>
+      <description ext:format="html"><p ext:format="ignored">Eggs</p></description>
+    </item>
+  </channel>`), c, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	credit := ext.StringField("credit")
+	format := ext.StringField("format")
+	note := ext.StringField("note")
+
+	if v, ok := credit.Get(&c.Image.Extension.Store); !ok || v != "Jane" {
+		t.Errorf("channel image credit should be 'Jane', got '%s' (%v)", v, ok)
+	}
+
+	item := c.Items[0]
+	if v, ok := format.Get(&item.Description.Extension.Store); !ok || v != "html" {
+		t.Errorf("item description format should be 'html', got '%s' (%v)", v, ok)
+	}
+	if _, ok := format.Get(&c.Description.Extension.Store); ok {
+		t.Errorf("channel description should not get the item/description extension")
+	}
+
+	if v, ok := note.Get(&item.Title.Extension.Store); !ok || v != "item" {
+		t.Errorf("item title note should be 'item', got '%s' (%v)", v, ok)
+	}
+	if _, ok := note.Get(&c.Title.Extension.Store); ok {
+		t.Errorf("channel title should not get the item/title extension")
+	}
+}
+
+func NewTestTtl(content string) *Ttl {
+	t := NewTtl()
+	t.Content.Value = content
+
+	return t
+}
+
+func TestChannelPublisherHints(t *testing.T) {
+	c := NewChannel()
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+  <channel>
+    <title>Liftoff News</title>
+    <link>http://liftoff.msfc.nasa.gov/</link>
+    <description>Liftoff to Space Exploration.</description>
+    <ttl>60</ttl>
+    <cloud domain="rpc.sys.com" port="80" path="/RPC2" registerProcedure="pingMe" protocol="soap"/>
+    <textInput>
+      <title>Search</title>
+      <description>Search the archives</description>
+      <name>q</name>
+      <link>http://liftoff.msfc.nasa.gov/search</link>
+    </textInput>
+    <skipHours><hour>1</hour><hour>2</hour></skipHours>
+    <skipDays><day>Sunday</day></skipDays>
+  </channel>`), c, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if minutes, ok := c.Ttl.Minutes(); !ok || minutes != 60 || c.Ttl.Duration() != time.Hour {
+		t.Errorf("ttl should be 60 minutes, got %v (%v)", minutes, ok)
+	}
+
+	if port, ok := c.Cloud.PortNumber(); !ok || port != 80 {
+		t.Errorf("cloud port should be 80, got %v (%v)", port, ok)
+	}
+
+	if c.TextInput.Name.String() != "q" {
+		t.Errorf("textInput name should be 'q', got '%s'", c.TextInput.Name.String())
+	}
+
+	if !c.SkipHours.Has(2) || c.SkipHours.Has(3) || !c.SkipDays.Has(time.Sunday) || c.SkipDays.Has(time.Monday) {
+		t.Errorf("skip hints are invalid: %v %v", c.SkipHours.Hours, c.SkipDays.Days)
+	}
+
+	var testdata = []struct {
+		XML           string
+		ExpectedError xmlutils.ParserError
+	}{
+		{`<ttl>-5</ttl>`, xmlutils.NewError(NotPositiveNumber, "")},
+		{`<ttl>one hour</ttl>`, xmlutils.NewError(NotPositiveNumber, "")},
+		{`<skipHours><hour>25</hour></skipHours>`, xmlutils.NewError(HourNotValid, "")},
+		{`<skipDays><day>Funday</day></skipDays>`, xmlutils.NewError(DayNotValid, "")},
+		{`<textInput><title>Search</title></textInput>`, xmlutils.NewError(MissingAttribute, "")},
+		{`<textInput><title>a</title><description>b</description><name>c</name><link>http://a.org</link></textInput>
+		  <textInput><title>a</title><description>b</description><name>c</name><link>http://a.org</link></textInput>`, xmlutils.NewError(AttributeDuplicated, "")},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, test := range testdata {
+		testcase := xmlutils.TestVisitor{
+			XML: `<channel><title>Liftoff News</title><link>http://liftoff.msfc.nasa.gov/</link>
+			  <description>Liftoff to Space Exploration.</description>` + test.XML + `</channel>`,
+			ExpectedError:      test.ExpectedError,
+			VisitorConstructor: testChannelConstructor,
+			Validator:          func(actual, expected xmlutils.Visitor) error { return nil },
+		}
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
+
+func TestChannelLanguage(t *testing.T) {
+	c := NewChannel()
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+  <channel>
+    <title>أخبار</title>
+    <link>http://example.org/</link>
+    <description>desc</description>
+    <language>ar-EG</language>
+    <item><title>a</title></item>
+    <item xml:lang="en"><title>b</title></item>
+  </channel>`), c, &checker, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if c.EffectiveLang() != "ar-EG" || c.Direction() != xmlutils.RightToLeft {
+		t.Errorf("channel should be ar-EG (rtl), got %s (%s)", c.EffectiveLang(), c.Direction())
+	}
+
+	if c.Items[0].EffectiveLang() != "ar-EG" || c.Items[0].Direction() != xmlutils.RightToLeft {
+		t.Errorf("item should inherit ar-EG (rtl), got %s (%s)", c.Items[0].EffectiveLang(), c.Items[0].Direction())
+	}
+
+	if c.Items[1].EffectiveLang() != "en" || c.Items[1].Direction() != xmlutils.LeftToRight {
+		t.Errorf("item xml:lang should override the channel language, got %s (%s)", c.Items[1].EffectiveLang(), c.Items[1].Direction())
+	}
+
+	testcase := xmlutils.TestVisitor{
+		XML: `<channel><title>Liftoff News</title><link>http://liftoff.msfc.nasa.gov/</link>
+		  <description>Liftoff to Space Exploration.</description><language>english</language></channel>`,
+		ExpectedError:      xmlutils.NewError(xmlutils.LanguageNotValid, ""),
+		VisitorConstructor: testChannelConstructor,
+		Validator:          func(actual, expected xmlutils.Visitor) error { return nil },
+	}
+
+	if err := testcase.CheckTestCase(); err != nil {
+		t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+	}
+}
@@ -2,6 +2,7 @@ package rss
 
 import (
 	"encoding/xml"
+	"strconv"
 
 	"github.com/jloup/utils"
 	"github.com/jloup/xml/feed/extension"
@@ -24,19 +25,19 @@ func NewCloud() *Cloud {
 	c := Cloud{depth: xmlutils.NewDepthWatcher()}
 
 	c.Domain = xmlutils.NewElement("domain", "", xmlutils.Nop)
-	c.Domain.SetOccurence(xmlutils.NewOccurence("domain", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	c.Domain.SetOccurence(xmlutils.NewOccurence("domain", existsAndIsUnique))
 
-	c.Port = xmlutils.NewElement("port", "", xmlutils.Nop)
-	c.Port.SetOccurence(xmlutils.NewOccurence("port", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	c.Port = xmlutils.NewElement("port", "", IsValidPort)
+	c.Port.SetOccurence(xmlutils.NewOccurence("port", existsAndIsUnique))
 
 	c.Path = xmlutils.NewElement("path", "", xmlutils.Nop)
-	c.Path.SetOccurence(xmlutils.NewOccurence("path", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	c.Path.SetOccurence(xmlutils.NewOccurence("path", existsAndIsUnique))
 
 	c.RegisterProcedure = xmlutils.NewElement("registerProcedure", "", xmlutils.Nop)
-	c.RegisterProcedure.SetOccurence(xmlutils.NewOccurence("registerProcedure", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	c.RegisterProcedure.SetOccurence(xmlutils.NewOccurence("registerProcedure", existsAndIsUnique))
 
-	c.Protocol = xmlutils.NewElement("protocol", "", xmlutils.Nop)
-	c.Protocol.SetOccurence(xmlutils.NewOccurence("protocol", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	c.Protocol = xmlutils.NewElement("protocol", "", IsValidCloudProtocol)
+	c.Protocol.SetOccurence(xmlutils.NewOccurence("protocol", existsAndIsUnique))
 
 	return &c
 }
@@ -59,6 +60,7 @@ func (c *Cloud) reset() {
 
 func (c *Cloud) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if c.depth.IsRoot() {
+		c.Extension = extension.InitExtension(el.Path, c.Extension.Manager)
 		c.reset()
 		for _, attr := range el.Attr {
 			switch attr.Name.Space {
@@ -115,3 +117,13 @@ func (c *Cloud) validate() xmlutils.ParserError {
 
 	return error.ErrorObject()
 }
+
+// PortNumber returns the port as an int; ok is false when it is missing or not valid
+func (c *Cloud) PortNumber() (port int, ok bool) {
+	if IsValidPort("port", c.Port.Value) != nil {
+		return 0, false
+	}
+
+	port, _ = strconv.Atoi(c.Port.Value)
+	return port, true
+}
@@ -97,6 +97,18 @@ func TestCloudBasic(t *testing.T) {
 			xmlutils.NewError(MissingAttribute, ""),
 			NewTestCloud("rpc.sys.com", "80", "/RPC2", "myCloud.rssPleaseNotify", ""),
 		},
+		{`<cloud domain="rpc.sys.com" port="http" path="/RPC2" registerProcedure="myCloud.rssPleaseNotify" protocol="xml-rpc" />`,
+			xmlutils.NewError(PortNotValid, ""),
+			NewTestCloud("rpc.sys.com", "http", "/RPC2", "myCloud.rssPleaseNotify", "xml-rpc"),
+		},
+		{`<cloud domain="rpc.sys.com" port="70000" path="/RPC2" registerProcedure="myCloud.rssPleaseNotify" protocol="xml-rpc" />`,
+			xmlutils.NewError(PortNotValid, ""),
+			NewTestCloud("rpc.sys.com", "70000", "/RPC2", "myCloud.rssPleaseNotify", "xml-rpc"),
+		},
+		{`<cloud domain="rpc.sys.com" port="80" path="/RPC2" registerProcedure="myCloud.rssPleaseNotify" protocol="ftp" />`,
+			xmlutils.NewError(CloudProtocolNotValid, ""),
+			NewTestCloud("rpc.sys.com", "80", "/RPC2", "myCloud.rssPleaseNotify", "ftp"),
+		},
 	}
 
 	nbErrors := 0
@@ -112,3 +124,17 @@ func TestCloudBasic(t *testing.T) {
 
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestCloudPortNumber(t *testing.T) {
+	if port, ok := NewTestCloud("rpc.sys.com", "8080", "/RPC2", "", "").PortNumber(); !ok || port != 8080 {
+		t.Errorf("port should be 8080, got %v (%v)", port, ok)
+	}
+
+	if _, ok := NewTestCloud("rpc.sys.com", "0", "/RPC2", "", "").PortNumber(); ok {
+		t.Errorf("port 0 should not be valid")
+	}
+
+	if _, ok := NewCloud().PortNumber(); ok {
+		t.Errorf("missing port should not be valid")
+	}
+}
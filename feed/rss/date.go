@@ -36,6 +36,7 @@ func NewDateExt(manager extension.Manager) *Date {
 
 func (d *Date) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if d.depth.IsRoot() {
+		d.Extension = extension.InitExtension(el.Path, d.Extension.Manager, "date")
 		for _, attr := range el.Attr {
 			d.Extension.ProcessAttr(attr, d)
 		}
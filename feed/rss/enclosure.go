@@ -2,6 +2,8 @@ package rss
 
 import (
 	"encoding/xml"
+	"mime"
+	"strconv"
 
 	"github.com/jloup/utils"
 	"github.com/jloup/xml/feed/extension"
@@ -21,14 +23,14 @@ type Enclosure struct {
 func NewEnclosure() *Enclosure {
 	e := Enclosure{depth: xmlutils.NewDepthWatcher()}
 
-	e.Url = xmlutils.NewElement("url", "", xmlutils.Nop)
-	e.Url.SetOccurence(xmlutils.NewOccurence("url", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	e.Url = xmlutils.NewElement("url", "", IsAbsoluteIRI)
+	e.Url.SetOccurence(xmlutils.NewOccurence("url", existsAndIsUnique))
 
-	e.Length = xmlutils.NewElement("length", "", xmlutils.Nop)
-	e.Length.SetOccurence(xmlutils.NewOccurence("length", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	e.Length = xmlutils.NewElement("length", "", IsValidLength)
+	e.Length.SetOccurence(xmlutils.NewOccurence("length", existsAndIsUnique))
 
-	e.Type = xmlutils.NewElement("type", "", xmlutils.Nop)
-	e.Type.SetOccurence(xmlutils.NewOccurence("type", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	e.Type = xmlutils.NewElement("type", "", IsValidMIME)
+	e.Type.SetOccurence(xmlutils.NewOccurence("type", existsAndIsUnique))
 
 	return &e
 }
@@ -48,6 +50,7 @@ func (e *Enclosure) reset() {
 
 func (e *Enclosure) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if e.depth.IsRoot() {
+		e.Extension = extension.InitExtension(el.Path, e.Extension.Manager)
 		e.reset()
 		for _, attr := range el.Attr {
 			switch attr.Name.Space {
@@ -97,3 +100,25 @@ func (e *Enclosure) validate() xmlutils.ParserError {
 
 	return error.ErrorObject()
 }
+
+// LengthBytes returns the size of the enclosure in bytes; ok is false when it
+// is missing or not valid
+func (e *Enclosure) LengthBytes() (length int64, ok bool) {
+	length, err := strconv.ParseInt(e.Length.Value, 10, 64)
+	if err != nil || length < 0 {
+		return 0, false
+	}
+	return length, true
+}
+
+// MediaType returns the lowercased media type of the enclosure and its
+// parameters (see mime.ParseMediaType); ok is false when it is missing or not
+// valid
+func (e *Enclosure) MediaType() (mediatype string, params map[string]string, ok bool) {
+	if IsValidMIME("type", e.Type.Value) != nil {
+		return "", nil, false
+	}
+
+	mediatype, params, _ = mime.ParseMediaType(e.Type.Value)
+	return mediatype, params, true
+}
@@ -78,6 +78,22 @@ func TestEnclosureBasic(t *testing.T) {
 			xmlutils.NewError(MissingAttribute, ""),
 			NewTestEnclosure("http://www.scripting.com/mp3s/weatherReportSuite.mp3", "12216320", ""),
 		},
+		{`<enclosure url="mp3s/weatherReportSuite.mp3" length="12216320" type="audio/mpeg" />`,
+			xmlutils.NewError(IriNotAbsolute, ""),
+			NewTestEnclosure("mp3s/weatherReportSuite.mp3", "12216320", "audio/mpeg"),
+		},
+		{`<enclosure url="http://www.scripting.com/mp3s/weatherReportSuite.mp3" length="12 MB" type="audio/mpeg" />`,
+			xmlutils.NewError(NotPositiveNumber, ""),
+			NewTestEnclosure("http://www.scripting.com/mp3s/weatherReportSuite.mp3", "12 MB", "audio/mpeg"),
+		},
+		{`<enclosure url="http://www.scripting.com/mp3s/weatherReportSuite.mp3" length="12216320" type="mp3" />`,
+			xmlutils.NewError(IsNotMIME, ""),
+			NewTestEnclosure("http://www.scripting.com/mp3s/weatherReportSuite.mp3", "12216320", "mp3"),
+		},
+		{`<enclosure url="http://www.scripting.com/mp3s/weatherReportSuite.mp3" length="5000000000" type="video/mp4; codecs=avc1" />`,
+			nil,
+			NewTestEnclosure("http://www.scripting.com/mp3s/weatherReportSuite.mp3", "5000000000", "video/mp4; codecs=avc1"),
+		},
 	}
 
 	nbErrors := 0
@@ -93,3 +109,25 @@ func TestEnclosureBasic(t *testing.T) {
 
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestEnclosureTypedValues(t *testing.T) {
+	e := NewTestEnclosure("http://example.org/a.mp4", "5000000000", "Video/MP4; codecs=avc1")
+
+	if length, ok := e.LengthBytes(); !ok || length != 5000000000 {
+		t.Errorf("length should be 5000000000, got %v (%v)", length, ok)
+	}
+
+	if mediatype, params, ok := e.MediaType(); !ok || mediatype != "video/mp4" || params["codecs"] != "avc1" {
+		t.Errorf("unexpected media type %s %v (%v)", mediatype, params, ok)
+	}
+
+	e = NewTestEnclosure("http://example.org/a.mp4", "-1", "mp4")
+
+	if _, ok := e.LengthBytes(); ok {
+		t.Errorf("negative length should not be valid")
+	}
+
+	if _, _, ok := e.MediaType(); ok {
+		t.Errorf("'mp4' should not be a valid media type")
+	}
+}
@@ -13,4 +13,13 @@ var (
 	CannotFlush              = utils.InitFlag(&xmlutils.ErrorFlagCounter, "CannotFlush")
 	DateFormat               = utils.InitFlag(&xmlutils.ErrorFlagCounter, "DateFormat")
 	IriNotValid              = utils.InitFlag(&xmlutils.ErrorFlagCounter, "IriNotValid")
+	NotPositiveNumber        = utils.InitFlag(&xmlutils.ErrorFlagCounter, "NotPositiveNumber")
+	HourNotValid             = utils.InitFlag(&xmlutils.ErrorFlagCounter, "HourNotValid")
+	DayNotValid              = utils.InitFlag(&xmlutils.ErrorFlagCounter, "DayNotValid")
+	PortNotValid             = utils.InitFlag(&xmlutils.ErrorFlagCounter, "PortNotValid")
+	CloudProtocolNotValid    = utils.InitFlag(&xmlutils.ErrorFlagCounter, "CloudProtocolNotValid")
+	IriNotAbsolute           = utils.InitFlag(&xmlutils.ErrorFlagCounter, "IriNotAbsolute")
+	IsNotMIME                = utils.InitFlag(&xmlutils.ErrorFlagCounter, "IsNotMIME")
+	ImageTooLarge            = utils.InitFlag(&xmlutils.ErrorFlagCounter, "ImageTooLarge")
+	EnclosureDuplicated      = utils.InitFlag(&xmlutils.ErrorFlagCounter, "EnclosureDuplicated")
 )
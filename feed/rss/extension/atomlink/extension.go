@@ -0,0 +1,28 @@
+// Package atomlink implements atom:link extension (http://www.w3.org/2005/Atom) for RSS feed, e.g. self, hub or paging links of a channel
+package atomlink
+
+import (
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/extension"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+const NS = "http://www.w3.org/2005/Atom"
+
+func AddToManager(manager *extension.Manager) {
+	// a channel or an item may have several links
+	manager.AddElementExtension("channel", LINK, NewLinkElement, func(o *xmlutils.Occurence) xmlutils.ParserError { return nil })
+	manager.AddElementExtension("item", LINK, NewLinkElement, func(o *xmlutils.Occurence) xmlutils.ParserError { return nil })
+}
+
+// GetLinks returns the atom:link elements of the channel or item store, in
+// document order, e.g. GetLinks(&c.Extension.Store)
+func GetLinks(store *extension.Store) atom.Links {
+	v, _ := extension.GetAll[*Link](store, LINK)
+
+	var links atom.Links
+	for _, link := range v {
+		links = append(links, link.Link)
+	}
+	return links
+}
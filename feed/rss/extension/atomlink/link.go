@@ -0,0 +1,39 @@
+package atomlink
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/extension"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// element names are lowercased by the parser, namespace included
+var LINK = xml.Name{Space: strings.ToLower(NS), Local: "link"}
+
+// Link is an atom:link within a RSS channel or item
+type Link struct {
+	*atom.Link
+}
+
+func NewLinkElement() extension.Element {
+	return &Link{atom.NewLink()}
+}
+
+func (l *Link) Name() xml.Name {
+	return LINK
+}
+
+func (l *Link) SetParent(parent xmlutils.Visitor) {
+	l.Parent = parent
+}
+
+func (l *Link) String() string {
+	return l.Href.String()
+}
+
+// Validate does nothing: the link is validated once parsed
+func (l *Link) Validate() xmlutils.ParserError {
+	return nil
+}
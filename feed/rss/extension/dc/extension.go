@@ -1,4 +1,4 @@
-// Package dc implements dc:creator extension (http://purl.org/dc/elements/1.1/) for RSS feed
+// Package dc implements dc:creator and dc:subject extensions (http://purl.org/dc/elements/1.1/) for RSS feed
 package dc
 
 import (
@@ -11,14 +11,23 @@ const NS = "http://purl.org/dc/elements/1.1/"
 
 func AddToManager(manager *extension.Manager) {
 	manager.AddElementExtension("item", CREATOR, NewCreatorElement, xmlutils.UniqueValidator(rss.AttributeDuplicated))
+	// an item may have several subjects
+	manager.AddElementExtension("item", SUBJECT, NewSubjectElement, func(o *xmlutils.Occurence) xmlutils.ParserError { return nil })
 
 }
 
 func GetCreator(item *rss.Item) (*rss.BasicElement, bool) {
-	itf, ok := item.Extension.Store.GetItf(CREATOR)
-	if !ok {
-		return nil, false
+	v, err := extension.Get[*rss.BasicElement](&item.Extension.Store, CREATOR)
+	return v, err == nil
+}
+
+// GetSubjects returns the dc:subject elements of item, in document order
+func GetSubjects(item *rss.Item) []*rss.BasicElement {
+	subjects, _ := extension.GetAll[*Subject](&item.Extension.Store, SUBJECT)
+
+	v := make([]*rss.BasicElement, 0, len(subjects))
+	for _, subject := range subjects {
+		v = append(v, subject.BasicElement)
 	}
-	i, ok := itf.(*rss.BasicElement)
-	return i, ok
+	return v
 }
@@ -0,0 +1,89 @@
+package dc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+type testDcItem struct {
+	XML              string
+	ExpectedError    xmlutils.ParserError
+	ExpectedCreator  string
+	ExpectedSubjects []string
+}
+
+func testDcItemConstructor() xmlutils.Visitor {
+	manager := extension.Manager{}
+	AddToManager(&manager)
+
+	return rss.NewItemExt(manager)
+}
+
+func _TestDcItemToTestVisitor(t testDcItem) xmlutils.TestVisitor {
+	customError := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	customError.EnableErrorChecking("item", rss.AttributeDuplicated)
+
+	testVisitor := xmlutils.TestVisitor{
+		XML:                `<item xmlns:dc="http://purl.org/dc/elements/1.1/">` + t.XML + `</item>`,
+		ExpectedError:      nil,
+		VisitorConstructor: testDcItemConstructor,
+		Validator: func(actual xmlutils.Visitor, expected xmlutils.Visitor) error {
+			item := actual.(*rss.Item)
+
+			creator := ""
+			if c, ok := GetCreator(item); ok {
+				creator = c.String()
+			}
+			if creator != t.ExpectedCreator {
+				return fmt.Errorf("creator is invalid '%s' (expected) vs '%s'", t.ExpectedCreator, creator)
+			}
+
+			var subjects []string
+			for _, s := range GetSubjects(item) {
+				subjects = append(subjects, s.String())
+			}
+			if strings.Join(subjects, "|") != strings.Join(t.ExpectedSubjects, "|") {
+				return fmt.Errorf("subjects are invalid %v (expected) vs %v", t.ExpectedSubjects, subjects)
+			}
+
+			return nil
+		},
+		CustomError: &customError,
+	}
+
+	if t.ExpectedError != nil {
+		testVisitor.ExpectedError = t.ExpectedError
+	}
+
+	return testVisitor
+}
+
+func TestDcItemBasic(t *testing.T) {
+
+	var testdata = []testDcItem{
+		{`<dc:creator>Jane Doe</dc:creator>`, nil, "Jane Doe", nil},
+		{`<dc:subject>go</dc:subject><dc:subject>xml</dc:subject>`, nil, "", []string{"go", "xml"}},
+		{`<dc:creator>Jane Doe</dc:creator><dc:subject>feeds</dc:subject>`, nil, "Jane Doe", []string{"feeds"}},
+		{``, nil, "", nil},
+		{`<dc:creator>Jane Doe</dc:creator><dc:creator>John Doe</dc:creator>`, xmlutils.NewError(rss.AttributeDuplicated, ""), "Jane Doe", nil},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, testitem := range testdata {
+		testcase := _TestDcItemToTestVisitor(testitem)
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
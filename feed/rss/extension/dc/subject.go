@@ -0,0 +1,32 @@
+package dc
+
+import (
+	"encoding/xml"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+var SUBJECT = xml.Name{Space: NS, Local: "subject"}
+
+// Subject is a dc:subject, a category of its item
+type Subject struct {
+	*rss.BasicElement
+}
+
+func NewSubjectElement() extension.Element {
+	s := rss.NewBasicElement()
+
+	s.Content = xmlutils.NewElement("subject", "", xmlutils.Nop)
+
+	return &Subject{s}
+}
+
+// Categories implements extension.Categorizer
+func (s *Subject) Categories() []string {
+	if s.Content.Value == "" {
+		return nil
+	}
+	return []string{s.Content.Value}
+}
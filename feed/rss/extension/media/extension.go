@@ -0,0 +1,46 @@
+// Package media implements media:keywords of Media RSS (http://search.yahoo.com/mrss/) for RSS feed, in items or in their media:group
+package media
+
+import (
+	"strings"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+const NS = "http://search.yahoo.com/mrss/"
+
+func AddToManager(manager *extension.Manager) {
+	manager.AddElementExtension("item", KEYWORDS, NewKeywordsElement, xmlutils.UniqueValidator(rss.AttributeDuplicated))
+	// an item may have several groups, e.g. one per media
+	manager.AddElementExtension("item", GROUP, NewGroupElement, func(o *xmlutils.Occurence) xmlutils.ParserError { return nil })
+}
+
+// GetKeywords returns the media:keywords of item, then the ones of its
+// media:group, in document order
+func GetKeywords(item *rss.Item) []string {
+	var keywords []string
+
+	if k, err := extension.Get[*Keywords](&item.Extension.Store, KEYWORDS); err == nil {
+		keywords = append(keywords, k.Categories()...)
+	}
+
+	groups, _ := extension.GetAll[*Group](&item.Extension.Store, GROUP)
+	for _, group := range groups {
+		keywords = append(keywords, group.Keywords...)
+	}
+
+	return keywords
+}
+
+// splitKeywords splits the comma separated keywords of s
+func splitKeywords(s string) []string {
+	var keywords []string
+	for _, keyword := range strings.Split(s, ",") {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
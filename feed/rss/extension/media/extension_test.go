@@ -0,0 +1,84 @@
+package media
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+type testMediaItem struct {
+	XML              string
+	ExpectedError    xmlutils.ParserError
+	ExpectedKeywords []string
+}
+
+func testMediaItemConstructor() xmlutils.Visitor {
+	manager := extension.Manager{}
+	AddToManager(&manager)
+
+	return rss.NewItemExt(manager)
+}
+
+func _TestMediaItemToTestVisitor(t testMediaItem) xmlutils.TestVisitor {
+	customError := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	customError.EnableErrorChecking("item", rss.AttributeDuplicated)
+
+	testVisitor := xmlutils.TestVisitor{
+		XML:                `<item xmlns:media="http://search.yahoo.com/mrss/">` + t.XML + `</item>`,
+		ExpectedError:      nil,
+		VisitorConstructor: testMediaItemConstructor,
+		Validator: func(actual xmlutils.Visitor, expected xmlutils.Visitor) error {
+			item := actual.(*rss.Item)
+
+			keywords := GetKeywords(item)
+			if strings.Join(keywords, "|") != strings.Join(t.ExpectedKeywords, "|") {
+				return fmt.Errorf("keywords are invalid %v (expected) vs %v", t.ExpectedKeywords, keywords)
+			}
+
+			// duplicated keywords are kept in the store
+			if categories := item.Extension.Store.Categories(); t.ExpectedError == nil && strings.Join(categories, "|") != strings.Join(t.ExpectedKeywords, "|") {
+				return fmt.Errorf("categories are invalid %v (expected) vs %v", t.ExpectedKeywords, categories)
+			}
+
+			return nil
+		},
+		CustomError: &customError,
+	}
+
+	if t.ExpectedError != nil {
+		testVisitor.ExpectedError = t.ExpectedError
+	}
+
+	return testVisitor
+}
+
+func TestMediaItemBasic(t *testing.T) {
+
+	var testdata = []testMediaItem{
+		{`<media:keywords>go, xml,, feeds </media:keywords>`, nil, []string{"go", "xml", "feeds"}},
+		{`<media:group><media:title>video</media:title><media:keywords>go</media:keywords></media:group>`, nil, []string{"go"}},
+		{`<media:keywords>go</media:keywords>
+		  <media:group><media:content url="http://example.org/a.mp4"><media:keywords>xml</media:keywords></media:content></media:group>
+		  <media:group><media:keywords>feeds</media:keywords></media:group>`, nil, []string{"go", "xml", "feeds"}},
+		{``, nil, nil},
+		{`<media:keywords>go</media:keywords><media:keywords>xml</media:keywords>`, xmlutils.NewError(rss.AttributeDuplicated, ""), []string{"go"}},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, testitem := range testdata {
+		testcase := _TestMediaItemToTestVisitor(testitem)
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
@@ -0,0 +1,78 @@
+package media
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/jloup/xml/feed/extension"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+var GROUP = xml.Name{Space: NS, Local: "group"}
+
+// Group is a media:group of an item. Only the media:keywords it holds, directly
+// or in its media:content, are kept.
+type Group struct {
+	Keywords []string
+
+	Parent     xmlutils.Visitor
+	depth      xmlutils.DepthWatcher
+	inKeywords bool
+	text       string
+}
+
+func NewGroupElement() extension.Element {
+	return &Group{depth: xmlutils.NewDepthWatcher()}
+}
+
+func (g *Group) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	g.depth.Down()
+
+	if el.Name == KEYWORDS {
+		g.inKeywords = true
+		g.text = ""
+	}
+
+	return g, nil
+}
+
+func (g *Group) ProcessEndElement(el xml.EndElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if g.inKeywords {
+		g.Keywords = append(g.Keywords, splitKeywords(g.text)...)
+		g.inKeywords = false
+	}
+
+	if g.depth.Up() == xmlutils.RootLevel {
+		return g.Parent, g.Validate()
+	}
+
+	return g, nil
+}
+
+func (g *Group) ProcessCharData(el xml.CharData) (xmlutils.Visitor, xmlutils.ParserError) {
+	if g.inKeywords {
+		g.text += string(el)
+	}
+	return g, nil
+}
+
+func (g *Group) Validate() xmlutils.ParserError {
+	return nil
+}
+
+func (g *Group) Name() xml.Name {
+	return GROUP
+}
+
+func (g *Group) String() string {
+	return strings.Join(g.Keywords, ", ")
+}
+
+func (g *Group) SetParent(p xmlutils.Visitor) {
+	g.Parent = p
+}
+
+// Categories implements extension.Categorizer with the keywords of g
+func (g *Group) Categories() []string {
+	return g.Keywords
+}
@@ -0,0 +1,29 @@
+package media
+
+import (
+	"encoding/xml"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+var KEYWORDS = xml.Name{Space: NS, Local: "keywords"}
+
+// Keywords is a media:keywords of an item, comma separated
+type Keywords struct {
+	*rss.BasicElement
+}
+
+func NewKeywordsElement() extension.Element {
+	k := rss.NewBasicElement()
+
+	k.Content = xmlutils.NewElement("keywords", "", xmlutils.Nop)
+
+	return &Keywords{k}
+}
+
+// Categories implements extension.Categorizer with the split keywords
+func (k *Keywords) Categories() []string {
+	return splitKeywords(k.Content.Value)
+}
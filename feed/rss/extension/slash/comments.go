@@ -0,0 +1,19 @@
+package slash
+
+import (
+	"encoding/xml"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+var COMMENTS = xml.Name{Space: NS, Local: "comments"}
+
+func NewCommentsElement() extension.Element {
+	c := rss.NewBasicElement()
+
+	c.Content = xmlutils.NewElement("comments", "", rss.IsValidNumber)
+
+	return c
+}
@@ -0,0 +1,36 @@
+// Package slash implements slash:comments extension (http://purl.org/rss/1.0/modules/slash/) for RSS feed
+package slash
+
+import (
+	"strconv"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+const NS = "http://purl.org/rss/1.0/modules/slash/"
+
+func AddToManager(manager *extension.Manager) {
+	manager.AddElementExtension("item", COMMENTS, NewCommentsElement, xmlutils.UniqueValidator(rss.AttributeDuplicated))
+}
+
+func GetComments(item *rss.Item) (*rss.BasicElement, bool) {
+	v, err := extension.Get[*rss.BasicElement](&item.Extension.Store, COMMENTS)
+	return v, err == nil
+}
+
+// GetCommentCount returns the number of comments announced for the item
+func GetCommentCount(item *rss.Item) (int, bool) {
+	c, ok := GetComments(item)
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(c.String())
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	return n, true
+}
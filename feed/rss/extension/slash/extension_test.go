@@ -0,0 +1,79 @@
+package slash
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+type testSlashItem struct {
+	XML           string
+	ExpectedError xmlutils.ParserError
+	ExpectedCount int
+	ExpectedOk    bool
+}
+
+func testSlashItemConstructor() xmlutils.Visitor {
+	manager := extension.Manager{}
+	AddToManager(&manager)
+
+	return rss.NewItemExt(manager)
+}
+
+func _TestSlashItemToTestVisitor(t testSlashItem) xmlutils.TestVisitor {
+	customError := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	customError.EnableErrorChecking("item", rss.AttributeDuplicated)
+	customError.EnableErrorChecking("comments", rss.NotPositiveNumber)
+
+	testVisitor := xmlutils.TestVisitor{
+		XML:                `<item xmlns:slash="http://purl.org/rss/1.0/modules/slash/">` + t.XML + `</item>`,
+		ExpectedError:      nil,
+		VisitorConstructor: testSlashItemConstructor,
+		Validator: func(actual xmlutils.Visitor, expected xmlutils.Visitor) error {
+			item := actual.(*rss.Item)
+
+			count, ok := GetCommentCount(item)
+			if count != t.ExpectedCount || ok != t.ExpectedOk {
+				return fmt.Errorf("comment count is invalid %v, %v (expected) vs %v, %v", t.ExpectedCount, t.ExpectedOk, count, ok)
+			}
+
+			return nil
+		},
+		CustomError: &customError,
+	}
+
+	if t.ExpectedError != nil {
+		testVisitor.ExpectedError = t.ExpectedError
+	}
+
+	return testVisitor
+}
+
+func TestSlashItemBasic(t *testing.T) {
+
+	var testdata = []testSlashItem{
+		{`<slash:comments>42</slash:comments>`, nil, 42, true},
+		{`<slash:comments> 0 </slash:comments>`, nil, 0, true},
+		{``, nil, 0, false},
+		{`<slash:comments>many</slash:comments>`, xmlutils.NewError(rss.NotPositiveNumber, ""), 0, false},
+		{`<slash:comments>-3</slash:comments>`, xmlutils.NewError(rss.NotPositiveNumber, ""), 0, false},
+		{`<slash:comments>1</slash:comments><slash:comments>2</slash:comments>`, xmlutils.NewError(rss.AttributeDuplicated, ""), 1, true},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, testitem := range testdata {
+		testcase := _TestSlashItemToTestVisitor(testitem)
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
@@ -0,0 +1,10 @@
+package sy
+
+import (
+	"github.com/jloup/utils"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+var (
+	PeriodNotValid = utils.InitFlag(&xmlutils.ErrorFlagCounter, "PeriodNotValid")
+)
@@ -0,0 +1,61 @@
+// Package sy implements sy:updatePeriod and sy:updateFrequency extension (http://purl.org/rss/1.0/modules/syndication/) for RSS feed
+package sy
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+const NS = "http://purl.org/rss/1.0/modules/syndication/"
+
+func AddToManager(manager *extension.Manager) {
+	manager.AddElementExtension("channel", UPDATEPERIOD, NewUpdatePeriodElement, xmlutils.UniqueValidator(rss.AttributeDuplicated))
+	manager.AddElementExtension("channel", UPDATEFREQUENCY, NewUpdateFrequencyElement, xmlutils.UniqueValidator(rss.AttributeDuplicated))
+}
+
+func GetUpdatePeriod(c *rss.Channel) (*rss.BasicElement, bool) {
+	v, err := extension.Get[*rss.BasicElement](&c.Extension.Store, UPDATEPERIOD)
+	return v, err == nil
+}
+
+func GetUpdateFrequency(c *rss.Channel) (*rss.BasicElement, bool) {
+	v, err := extension.Get[*rss.BasicElement](&c.Extension.Store, UPDATEFREQUENCY)
+	return v, err == nil
+}
+
+// GetUpdateInterval returns the time between two updates of the channel, that
+// is updatePeriod divided by updateFrequency. As stated by the module, period
+// defaults to daily and frequency to 1; ok is false when neither is present or
+// when a value is not valid.
+func GetUpdateInterval(c *rss.Channel) (time.Duration, bool) {
+	periodEl, hasPeriod := GetUpdatePeriod(c)
+	frequencyEl, hasFrequency := GetUpdateFrequency(c)
+
+	if !hasPeriod && !hasFrequency {
+		return 0, false
+	}
+
+	period := periods["daily"]
+	if hasPeriod {
+		p, ok := periods[periodEl.String()]
+		if !ok {
+			return 0, false
+		}
+		period = p
+	}
+
+	frequency := 1
+	if hasFrequency {
+		f, err := strconv.Atoi(frequencyEl.String())
+		if err != nil || f <= 0 {
+			return 0, false
+		}
+		frequency = f
+	}
+
+	return period / time.Duration(frequency), true
+}
@@ -0,0 +1,79 @@
+package sy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+type testSyChannel struct {
+	XML              string
+	ExpectedError    xmlutils.ParserError
+	ExpectedInterval time.Duration
+	ExpectedOk       bool
+}
+
+func testSyChannelConstructor() xmlutils.Visitor {
+	manager := extension.Manager{}
+	AddToManager(&manager)
+
+	return rss.NewChannelExt(manager)
+}
+
+func _TestSyChannelToTestVisitor(t testSyChannel) xmlutils.TestVisitor {
+	customError := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	customError.EnableErrorChecking("updatePeriod", PeriodNotValid)
+	customError.EnableErrorChecking("updateFrequency", rss.NotPositiveNumber)
+	customError.EnableErrorChecking("channel", rss.AttributeDuplicated)
+
+	testVisitor := xmlutils.TestVisitor{
+		XML:                `<channel xmlns:sy="http://purl.org/rss/1.0/modules/syndication/">` + t.XML + `</channel>`,
+		ExpectedError:      nil,
+		VisitorConstructor: testSyChannelConstructor,
+		Validator: func(actual xmlutils.Visitor, expected xmlutils.Visitor) error {
+			interval, ok := GetUpdateInterval(actual.(*rss.Channel))
+			if ok != t.ExpectedOk || interval != t.ExpectedInterval {
+				return fmt.Errorf("update interval is invalid %s (%v) (expected) vs %s (%v)", t.ExpectedInterval, t.ExpectedOk, interval, ok)
+			}
+			return nil
+		},
+		CustomError: &customError,
+	}
+
+	if t.ExpectedError != nil {
+		testVisitor.ExpectedError = t.ExpectedError
+	}
+
+	return testVisitor
+}
+
+func TestSyChannelBasic(t *testing.T) {
+
+	var testdata = []testSyChannel{
+		{`<sy:updatePeriod>hourly</sy:updatePeriod><sy:updateFrequency>2</sy:updateFrequency>`, nil, 30 * time.Minute, true},
+		{`<sy:updatePeriod>weekly</sy:updatePeriod>`, nil, 7 * 24 * time.Hour, true},
+		{`<sy:updateFrequency>4</sy:updateFrequency>`, nil, 6 * time.Hour, true},
+		{``, nil, 0, false},
+		{`<sy:updatePeriod>fortnightly</sy:updatePeriod>`, xmlutils.NewError(PeriodNotValid, ""), 0, false},
+		{`<sy:updateFrequency>often</sy:updateFrequency>`, xmlutils.NewError(rss.NotPositiveNumber, ""), 0, false},
+		{`<sy:updatePeriod>daily</sy:updatePeriod><sy:updatePeriod>daily</sy:updatePeriod>`, xmlutils.NewError(rss.AttributeDuplicated, ""), 24 * time.Hour, true},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, testchannel := range testdata {
+		testcase := _TestSyChannelToTestVisitor(testchannel)
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
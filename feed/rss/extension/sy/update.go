@@ -0,0 +1,48 @@
+package sy
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// element names are lowercased by the parser, namespace included
+var UPDATEPERIOD = xml.Name{Space: strings.ToLower(NS), Local: "updateperiod"}
+var UPDATEFREQUENCY = xml.Name{Space: strings.ToLower(NS), Local: "updatefrequency"}
+
+var periods = map[string]time.Duration{
+	"hourly":  time.Hour,
+	"daily":   24 * time.Hour,
+	"weekly":  7 * 24 * time.Hour,
+	"monthly": 30 * 24 * time.Hour,
+	"yearly":  365 * 24 * time.Hour,
+}
+
+func IsValidPeriod(name, s string) xmlutils.ParserError {
+	if _, ok := periods[s]; ok {
+		return nil
+	}
+
+	return xmlutils.NewError(PeriodNotValid, fmt.Sprintf("%s '%s' should be one of hourly, daily, weekly, monthly or yearly", name, s))
+}
+
+func NewUpdatePeriodElement() extension.Element {
+	p := rss.NewBasicElement()
+
+	p.Content = xmlutils.NewElement("updateperiod", "", IsValidPeriod)
+
+	return p
+}
+
+func NewUpdateFrequencyElement() extension.Element {
+	f := rss.NewBasicElement()
+
+	f.Content = xmlutils.NewElement("updatefrequency", "", rss.IsValidNumber)
+
+	return f
+}
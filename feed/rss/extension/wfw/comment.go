@@ -0,0 +1,30 @@
+package wfw
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// element names are lowercased by the parser, namespace included
+var COMMENT = xml.Name{Space: strings.ToLower(NS), Local: "comment"}
+var COMMENTRSS = xml.Name{Space: strings.ToLower(NS), Local: "commentrss"}
+
+func NewCommentElement() extension.Element {
+	c := rss.NewBasicElement()
+
+	c.Content = xmlutils.NewElement("comment", "", rss.IsValidIRI)
+
+	return c
+}
+
+func NewCommentRssElement() extension.Element {
+	c := rss.NewBasicElement()
+
+	c.Content = xmlutils.NewElement("commentrss", "", rss.IsValidIRI)
+
+	return c
+}
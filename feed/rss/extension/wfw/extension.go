@@ -0,0 +1,27 @@
+// Package wfw implements wfw:comment and wfw:commentRss extension (http://wellformedweb.org/CommentAPI/) for RSS feed
+package wfw
+
+import (
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+const NS = "http://wellformedweb.org/CommentAPI/"
+
+func AddToManager(manager *extension.Manager) {
+	manager.AddElementExtension("item", COMMENT, NewCommentElement, xmlutils.UniqueValidator(rss.AttributeDuplicated))
+	manager.AddElementExtension("item", COMMENTRSS, NewCommentRssElement, xmlutils.UniqueValidator(rss.AttributeDuplicated))
+}
+
+// GetComment returns the URL accepting comment posts for the item
+func GetComment(item *rss.Item) (*rss.BasicElement, bool) {
+	v, err := extension.Get[*rss.BasicElement](&item.Extension.Store, COMMENT)
+	return v, err == nil
+}
+
+// GetCommentRss returns the URL of the item's comments feed
+func GetCommentRss(item *rss.Item) (*rss.BasicElement, bool) {
+	v, err := extension.Get[*rss.BasicElement](&item.Extension.Store, COMMENTRSS)
+	return v, err == nil
+}
@@ -0,0 +1,93 @@
+package wfw
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+type testWfwItem struct {
+	XML                string
+	ExpectedError      xmlutils.ParserError
+	ExpectedComment    string
+	ExpectedCommentRss string
+}
+
+func testWfwItemConstructor() xmlutils.Visitor {
+	manager := extension.Manager{}
+	AddToManager(&manager)
+
+	return rss.NewItemExt(manager)
+}
+
+func _TestWfwItemToTestVisitor(t testWfwItem) xmlutils.TestVisitor {
+	customError := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	customError.EnableErrorChecking("item", rss.AttributeDuplicated)
+	customError.EnableErrorChecking("comment", rss.IriNotValid)
+	// errors are checked against the tag name as written in the document
+	customError.EnableErrorChecking("commentRss", rss.IriNotValid)
+
+	testVisitor := xmlutils.TestVisitor{
+		XML:                `<item xmlns:wfw="http://wellformedweb.org/CommentAPI/">` + t.XML + `</item>`,
+		ExpectedError:      nil,
+		VisitorConstructor: testWfwItemConstructor,
+		Validator: func(actual xmlutils.Visitor, expected xmlutils.Visitor) error {
+			item := actual.(*rss.Item)
+
+			comment := ""
+			if c, ok := GetComment(item); ok {
+				comment = c.String()
+			}
+			if comment != t.ExpectedComment {
+				return fmt.Errorf("comment is invalid '%s' (expected) vs '%s'", t.ExpectedComment, comment)
+			}
+
+			commentRss := ""
+			if c, ok := GetCommentRss(item); ok {
+				commentRss = c.String()
+			}
+			if commentRss != t.ExpectedCommentRss {
+				return fmt.Errorf("commentRss is invalid '%s' (expected) vs '%s'", t.ExpectedCommentRss, commentRss)
+			}
+
+			return nil
+		},
+		CustomError: &customError,
+	}
+
+	if t.ExpectedError != nil {
+		testVisitor.ExpectedError = t.ExpectedError
+	}
+
+	return testVisitor
+}
+
+func TestWfwItemBasic(t *testing.T) {
+
+	var testdata = []testWfwItem{
+		{`<wfw:comment>http://example.org/comments/1</wfw:comment>`, nil, "http://example.org/comments/1", ""},
+		{`<wfw:commentRss>http://example.org/comments/1/feed</wfw:commentRss>`, nil, "", "http://example.org/comments/1/feed"},
+		{`<wfw:comment>http://example.org/comments/1</wfw:comment>
+		  <wfw:commentRss>http://example.org/comments/1/feed</wfw:commentRss>`, nil, "http://example.org/comments/1", "http://example.org/comments/1/feed"},
+		{``, nil, "", ""},
+		{`<wfw:commentRss>http://exa mple.org/%zz</wfw:commentRss>`, xmlutils.NewError(rss.IriNotValid, ""), "", "http://exa mple.org/%zz"},
+		{`<wfw:comment>http://example.org/a</wfw:comment><wfw:comment>http://example.org/b</wfw:comment>`, xmlutils.NewError(rss.AttributeDuplicated, ""), "http://example.org/a", ""},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, testitem := range testdata {
+		testcase := _TestWfwItemToTestVisitor(testitem)
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
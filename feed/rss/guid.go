@@ -22,7 +22,7 @@ func NewGuid() *Guid {
 	g := Guid{depth: xmlutils.NewDepthWatcher()}
 
 	g.IsPermalink = xmlutils.NewElement("isPermalink", "true", xmlutils.Nop)
-	g.IsPermalink.SetOccurence(xmlutils.NewOccurence("isPermalink", xmlutils.UniqueValidator(AttributeDuplicated)))
+	g.IsPermalink.SetOccurence(xmlutils.NewOccurence("isPermalink", isUnique))
 
 	return &g
 }
@@ -41,6 +41,7 @@ func (g *Guid) reset() {
 
 func (g *Guid) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if g.depth.IsRoot() {
+		g.Extension = extension.InitExtension(el.Path, g.Extension.Manager)
 		g.reset()
 		for _, attr := range el.Attr {
 			switch attr.Name.Space {
@@ -82,3 +83,9 @@ func (g *Guid) validate() xmlutils.ParserError {
 
 	return error.ErrorObject()
 }
+
+// Permalink reports whether the guid is a permanent link to the item: true
+// unless isPermaLink is "false"
+func (g *Guid) Permalink() bool {
+	return !strings.EqualFold(strings.TrimSpace(g.IsPermalink.Value), "false")
+}
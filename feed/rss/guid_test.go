@@ -81,3 +81,11 @@ func TestGuidBasic(t *testing.T) {
 
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestGuidPermalink(t *testing.T) {
+	for value, expected := range map[string]bool{"true": true, "false": false, "FALSE": false, "": true} {
+		if NewTestGuid(value, "").Permalink() != expected {
+			t.Errorf("isPermaLink='%s' should be %v", value, expected)
+		}
+	}
+}
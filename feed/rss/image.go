@@ -2,6 +2,7 @@ package rss
 
 import (
 	"encoding/xml"
+	"strconv"
 
 	"github.com/jloup/utils"
 	"github.com/jloup/xml/feed/extension"
@@ -53,23 +54,23 @@ func NewImageExt(manager extension.Manager) *Image {
 }
 
 func (i *Image) init() {
-	i.Url.Content = xmlutils.NewElement("url", "", xmlutils.Nop)
-	i.Url.Content.SetOccurence(xmlutils.NewOccurence("url", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	i.Url.Content = xmlutils.NewElement("url", "", IsAbsoluteIRI)
+	i.Url.Content.SetOccurence(xmlutils.NewOccurence("url", existsAndIsUnique))
 
 	i.Title.Content = xmlutils.NewElement("title", "", xmlutils.Nop)
-	i.Title.Content.SetOccurence(xmlutils.NewOccurence("title", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	i.Title.Content.SetOccurence(xmlutils.NewOccurence("title", existsAndIsUnique))
 
-	i.Link.Content = xmlutils.NewElement("link", "", xmlutils.Nop)
-	i.Link.Content.SetOccurence(xmlutils.NewOccurence("link", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	i.Link.Content = xmlutils.NewElement("link", "", IsAbsoluteIRI)
+	i.Link.Content.SetOccurence(xmlutils.NewOccurence("link", existsAndIsUnique))
 
-	i.Width.Content = xmlutils.NewElement("width", "", xmlutils.Nop)
-	i.Width.Content.SetOccurence(xmlutils.NewOccurence("width", xmlutils.UniqueValidator(AttributeDuplicated)))
+	i.Width.Content = xmlutils.NewElement("width", "", IsValidImageWidth)
+	i.Width.Content.SetOccurence(xmlutils.NewOccurence("width", isUnique))
 
-	i.Height.Content = xmlutils.NewElement("height", "", xmlutils.Nop)
-	i.Height.Content.SetOccurence(xmlutils.NewOccurence("height", xmlutils.UniqueValidator(AttributeDuplicated)))
+	i.Height.Content = xmlutils.NewElement("height", "", IsValidImageHeight)
+	i.Height.Content.SetOccurence(xmlutils.NewOccurence("height", isUnique))
 
 	i.Description.Content = xmlutils.NewElement("description", "", xmlutils.Nop)
-	i.Description.Content.SetOccurence(xmlutils.NewOccurence("description", xmlutils.UniqueValidator(AttributeDuplicated)))
+	i.Description.Content.SetOccurence(xmlutils.NewOccurence("description", isUnique))
 
 	i.Url.Parent = i
 	i.Title.Parent = i
@@ -90,6 +91,7 @@ func (i *Image) reset() {
 
 func (i *Image) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if i.depth.IsRoot() {
+		i.Extension = extension.InitExtension(el.Path, i.Extension.Manager)
 		i.reset()
 		for _, attr := range el.Attr {
 			i.Extension.ProcessAttr(attr, i)
@@ -154,3 +156,26 @@ func (i *Image) validate() xmlutils.ParserError {
 
 	return error.ErrorObject()
 }
+
+func imageDimension(e *BasicElement, max, def int) int {
+	n, err := strconv.Atoi(e.String())
+	switch {
+	case err != nil || n < 0:
+		return def
+	case n > max:
+		return max
+	}
+	return n
+}
+
+// WidthPixels returns the width of the image, DefaultImageWidth when it is
+// missing or not valid; it is capped at MaxImageWidth
+func (i *Image) WidthPixels() int {
+	return imageDimension(i.Width, MaxImageWidth, DefaultImageWidth)
+}
+
+// HeightPixels returns the height of the image, DefaultImageHeight when it is
+// missing or not valid; it is capped at MaxImageHeight
+func (i *Image) HeightPixels() int {
+	return imageDimension(i.Height, MaxImageHeight, DefaultImageHeight)
+}
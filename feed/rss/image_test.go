@@ -131,6 +131,47 @@ var testdata = []testImage{
 		nil,
 		NewTestImage("http://writetheweb.com/images/mynetscape88.gif", "WriteTheWeb", "http://writetheweb.com", "", "", ""),
 	},
+	{`
+         <image>
+           <title>WriteTheWeb</title>
+           <url>/images/mynetscape88.gif</url>
+           <link>http://writetheweb.com</link>
+          </image>`,
+		xmlutils.NewError(IriNotAbsolute, ""),
+		NewTestImage("/images/mynetscape88.gif", "WriteTheWeb", "http://writetheweb.com", "", "", ""),
+	},
+	{`
+         <image>
+           <title>WriteTheWeb</title>
+           <url>http://writetheweb.com/images/mynetscape88.gif</url>
+           <link>http://writetheweb.com</link>
+           <width>200</width>
+           <height>31</height>
+          </image>`,
+		xmlutils.NewError(ImageTooLarge, ""),
+		NewTestImage("http://writetheweb.com/images/mynetscape88.gif", "WriteTheWeb", "http://writetheweb.com", "200", "31", ""),
+	},
+	{`
+         <image>
+           <title>WriteTheWeb</title>
+           <url>http://writetheweb.com/images/mynetscape88.gif</url>
+           <link>http://writetheweb.com</link>
+           <width>88</width>
+           <height>401</height>
+          </image>`,
+		xmlutils.NewError(ImageTooLarge, ""),
+		NewTestImage("http://writetheweb.com/images/mynetscape88.gif", "WriteTheWeb", "http://writetheweb.com", "88", "401", ""),
+	},
+	{`
+         <image>
+           <title>WriteTheWeb</title>
+           <url>http://writetheweb.com/images/mynetscape88.gif</url>
+           <link>http://writetheweb.com</link>
+           <width>wide</width>
+          </image>`,
+		xmlutils.NewError(NotPositiveNumber, ""),
+		NewTestImage("http://writetheweb.com/images/mynetscape88.gif", "WriteTheWeb", "http://writetheweb.com", "wide", "", ""),
+	},
 }
 
 func TestImageBasic(t *testing.T) {
@@ -147,3 +188,23 @@ func TestImageBasic(t *testing.T) {
 
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestImagePixels(t *testing.T) {
+	tests := []struct {
+		width, height                 string
+		expectedWidth, expectedHeight int
+	}{
+		{"100", "200", 100, 200},
+		{"", "", DefaultImageWidth, DefaultImageHeight},
+		{"wide", "-1", DefaultImageWidth, DefaultImageHeight},
+		{"1000", "1000", MaxImageWidth, MaxImageHeight},
+	}
+
+	for _, test := range tests {
+		i := NewTestImage("", "", "", test.width, test.height, "")
+
+		if w, h := i.WidthPixels(), i.HeightPixels(); w != test.expectedWidth || h != test.expectedHeight {
+			t.Errorf("%sx%s: expected %vx%v, got %vx%v", test.width, test.height, test.expectedWidth, test.expectedHeight, w, h)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	xmlutils "github.com/jloup/xml/utils"
 )
 
+// Item is a RSS item. Its Enclosures are kept in document order: RSS allows a
+// single one, the following ones are flagged EnclosureDuplicated.
 type Item struct {
 	Title       *BasicElement
 	Link        *BasicElement
@@ -15,7 +17,7 @@ type Item struct {
 	Author      *BasicElement
 	Categories  []*Category
 	Comments    *BasicElement
-	Enclosure   *Enclosure
+	Enclosures  []*Enclosure
 	Guid        *Guid
 	PubDate     *Date
 	Source      *Source
@@ -24,6 +26,9 @@ type Item struct {
 	Parent     xmlutils.Visitor
 	depth      xmlutils.DepthWatcher
 	Occurences xmlutils.OccurenceCollection
+	xmlLang    string
+	// ownLang is set when the item carries its own xml:lang
+	ownLang bool
 }
 
 func NewItem() *Item {
@@ -33,7 +38,6 @@ func NewItem() *Item {
 		Description: NewUnescapedContent(),
 		Author:      NewBasicElement(),
 		Comments:    NewBasicElement(),
-		Enclosure:   NewEnclosure(),
 		Guid:        NewGuid(),
 		PubDate:     NewDate(),
 		Source:      NewSource(),
@@ -53,7 +57,6 @@ func NewItemExt(manager extension.Manager) *Item {
 		Description: NewUnescapedContentExt(manager),
 		Author:      NewBasicElementExt(manager),
 		Comments:    NewBasicElementExt(manager),
-		Enclosure:   NewEnclosureExt(manager),
 		Guid:        NewGuidExt(manager),
 		PubDate:     NewDateExt(manager),
 		Source:      NewSourceExt(manager),
@@ -79,21 +82,20 @@ func (i *Item) init() {
 	i.Description.Parent = i
 	i.Author.Parent = i
 	i.Comments.Parent = i
-	i.Enclosure.Parent = i
 	i.Guid.Parent = i
 	i.PubDate.Parent = i
 	i.Source.Parent = i
 
 	i.Occurences = xmlutils.NewOccurenceCollection(
-		xmlutils.NewOccurence("title", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("link", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("description", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("author", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("comments", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("enclosure", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("guid", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("pubdate", xmlutils.UniqueValidator(AttributeDuplicated)),
-		xmlutils.NewOccurence("source", xmlutils.UniqueValidator(AttributeDuplicated)),
+		xmlutils.NewOccurence("title", isUnique),
+		xmlutils.NewOccurence("link", isUnique),
+		xmlutils.NewOccurence("description", isUnique),
+		xmlutils.NewOccurence("author", isUnique),
+		xmlutils.NewOccurence("comments", isUnique),
+		xmlutils.NewOccurence("enclosure", xmlutils.UniqueValidator(EnclosureDuplicated)),
+		xmlutils.NewOccurence("guid", isUnique),
+		xmlutils.NewOccurence("pubdate", isUnique),
+		xmlutils.NewOccurence("source", isUnique),
 	)
 }
 
@@ -103,8 +105,14 @@ func (i *Item) reset() {
 
 func (i *Item) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if i.depth.IsRoot() {
+		i.Extension = extension.InitExtension(el.Path, i.Extension.Manager)
 		i.reset()
+		i.xmlLang = el.Lang
+		i.ownLang = false
 		for _, attr := range el.Attr {
+			if attr.Name.Space == xmlutils.XML_NS && attr.Name.Local == "lang" {
+				i.ownLang = true
+			}
 			i.Extension.ProcessAttr(attr, i)
 		}
 	}
@@ -122,7 +130,7 @@ func (i *Item) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor,
 
 		case "description":
 			i.Occurences.Inc("description")
-			return i.Description, nil
+			return i.Description.start(el)
 
 		case "author":
 			i.Occurences.Inc("author")
@@ -140,7 +148,10 @@ func (i *Item) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor,
 
 		case "enclosure":
 			i.Occurences.Inc("enclosure")
-			return i.Enclosure.ProcessStartElement(el)
+			enclosure := NewEnclosureExt(i.Extension.Manager)
+			enclosure.Parent = i
+			i.Enclosures = append(i.Enclosures, enclosure)
+			return enclosure.ProcessStartElement(el)
 
 		case "guid":
 			i.Occurences.Inc("guid")
@@ -187,3 +198,24 @@ func (i *Item) validate() xmlutils.ParserError {
 
 	return err.ErrorObject()
 }
+
+// EffectiveLang returns the language of the item: its own xml:lang, else the
+// one of its channel (see Channel.EffectiveLang), as RSS language applies to
+// the whole channel, else the xml:lang in scope. It is empty when unknown.
+func (i *Item) EffectiveLang() string {
+	if i.ownLang {
+		return i.xmlLang
+	}
+
+	if c, ok := i.Parent.(*Channel); ok {
+		if lang := c.EffectiveLang(); lang != "" {
+			return lang
+		}
+	}
+	return i.xmlLang
+}
+
+// Direction returns the writing direction of the language of the item
+func (i *Item) Direction() xmlutils.Direction {
+	return xmlutils.LanguageDirection(i.EffectiveLang())
+}
@@ -13,7 +13,7 @@ func NewTestItem(
 	author *BasicElement,
 	cat []*Category,
 	comments *BasicElement,
-	enclosure *Enclosure,
+	enclosures []*Enclosure,
 	guid *Guid,
 	pubdate *Date,
 	source *Source,
@@ -27,7 +27,7 @@ func NewTestItem(
 	i.Author = author
 	i.Categories = cat
 	i.Comments = comments
-	i.Enclosure = enclosure
+	i.Enclosures = enclosures
 	i.Guid = guid
 	i.PubDate = pubdate
 	i.Source = source
@@ -90,8 +90,14 @@ func testItemValidator(actual xmlutils.Visitor, expected xmlutils.Visitor) error
 		return fmt.Errorf("Comments is invalid '%s' (expected) vs '%s'", i2.Comments.Content.Value, i1.Comments.Content.Value)
 	}
 
-	if err := testEnclosureValidator(i1.Enclosure, i2.Enclosure); err != nil {
-		return err
+	if len(i1.Enclosures) != len(i2.Enclosures) {
+		return fmt.Errorf("Item does not contain the right count of Enclosures %v (expected) vs %v", len(i2.Enclosures), len(i1.Enclosures))
+	}
+
+	for i, _ := range i1.Enclosures {
+		if err := testEnclosureValidator(i1.Enclosures[i], i2.Enclosures[i]); err != nil {
+			return err
+		}
 	}
 
 	if err := testGuidValidator(i1.Guid, i2.Guid); err != nil {
@@ -153,7 +159,7 @@ func TestItemBasic(t *testing.T) {
 					NewTestCategory("", "MUSIC"),
 				},
 				NewBasicElement(),
-				NewEnclosure(),
+				nil,
 				NewTestGuid("true", "http://liftoff.msfc.nasa.gov/2003/06/03.html#item573"),
 				NewTestDate("Tue, 03 Jun 2003 09:39:21 GMT"),
 				NewSource(),
@@ -171,7 +177,7 @@ func TestItemBasic(t *testing.T) {
 				NewBasicElement(),
 				nil,
 				NewBasicElement(),
-				NewEnclosure(),
+				nil,
 				NewGuid(),
 				NewDate(),
 				NewSource(),
@@ -189,7 +195,7 @@ func TestItemBasic(t *testing.T) {
 				NewBasicElement(),
 				nil,
 				NewBasicElement(),
-				NewEnclosure(),
+				nil,
 				NewGuid(),
 				NewDate(),
 				NewSource(),
@@ -206,7 +212,7 @@ func TestItemBasic(t *testing.T) {
 				NewBasicElement(),
 				nil,
 				NewBasicElement(),
-				NewEnclosure(),
+				nil,
 				NewGuid(),
 				NewDate(),
 				NewSource(),
@@ -225,7 +231,30 @@ func TestItemBasic(t *testing.T) {
 				NewBasicElement(),
 				nil,
 				NewBasicElement(),
-				NewEnclosure(),
+				nil,
+				NewGuid(),
+				NewDate(),
+				NewSource(),
+			),
+		},
+		{`
+                 <item>
+		  <title>Episode 1</title>
+		  <enclosure url="http://example.org/ep1.mp3" length="1000" type="audio/mpeg" />
+		  <enclosure url="http://example.org/ep1.ogg" length="2000" type="audio/ogg" />
+		  </item>`,
+			xmlutils.NewError(EnclosureDuplicated, ""),
+			NewTestItem(
+				NewTestBasicElement("Episode 1"),
+				NewBasicElement(),
+				NewUnescapedContent(),
+				NewBasicElement(),
+				nil,
+				NewBasicElement(),
+				[]*Enclosure{
+					NewTestEnclosure("http://example.org/ep1.mp3", "1000", "audio/mpeg"),
+					NewTestEnclosure("http://example.org/ep1.ogg", "2000", "audio/ogg"),
+				},
 				NewGuid(),
 				NewDate(),
 				NewSource(),
@@ -246,3 +275,37 @@ func TestItemBasic(t *testing.T) {
 
 	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
 }
+
+func TestItemSingleEnclosurePolicy(t *testing.T) {
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+	checker.DisableErrorChecking(xmlutils.AllError, EnclosureDuplicated)
+
+	testcase := _TestItemToTestVisitor(testItem{`
+                 <item>
+		  <title>Episode 1</title>
+		  <enclosure url="http://example.org/ep1.mp3" length="1000" type="audio/mpeg" />
+		  <enclosure url="http://example.org/ep1.ogg" length="2000" type="audio/ogg" />
+		  </item>`,
+		nil,
+		NewTestItem(
+			NewTestBasicElement("Episode 1"),
+			NewBasicElement(),
+			NewUnescapedContent(),
+			NewBasicElement(),
+			nil,
+			NewBasicElement(),
+			[]*Enclosure{
+				NewTestEnclosure("http://example.org/ep1.mp3", "1000", "audio/mpeg"),
+				NewTestEnclosure("http://example.org/ep1.ogg", "2000", "audio/ogg"),
+			},
+			NewGuid(),
+			NewDate(),
+			NewSource(),
+		),
+	})
+	testcase.CustomError = &checker
+
+	if err := testcase.CheckTestCase(); err != nil {
+		t.Errorf("several enclosures should be allowed once EnclosureDuplicated is disabled: %s", err)
+	}
+}
@@ -0,0 +1,60 @@
+package rss
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jloup/xml/feed/extension"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// SkipDays is the set of days in which aggregators may not read the channel
+type SkipDays struct {
+	Days []time.Weekday
+	skipList
+}
+
+func NewSkipDays() *SkipDays {
+	s := SkipDays{skipList: newSkipList("skipDays", "day")}
+	s.parse = s.parseDay
+
+	return &s
+}
+
+func NewSkipDaysExt(manager extension.Manager) *SkipDays {
+	s := NewSkipDays()
+	s.Extension = extension.InitExtension("skipdays", manager)
+
+	return s
+}
+
+func (s *SkipDays) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if s.depth.IsRoot() {
+		s.Days = nil
+	}
+
+	return s.skipList.ProcessStartElement(el)
+}
+
+func (s *SkipDays) parseDay(value string) xmlutils.ParserError {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if value == day.String() {
+			if !s.Has(day) {
+				s.Days = append(s.Days, day)
+			}
+			return nil
+		}
+	}
+
+	return xmlutils.NewError(DayNotValid, fmt.Sprintf("day '%s' should be one of Monday, Tuesday, Wednesday, Thursday, Friday, Saturday or Sunday", value))
+}
+
+// Has reports whether day is to be skipped
+func (s *SkipDays) Has(day time.Weekday) bool {
+	for _, d := range s.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
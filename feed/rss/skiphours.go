@@ -0,0 +1,58 @@
+package rss
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jloup/xml/feed/extension"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// SkipHours lists the hours (GMT, 0-23) in which aggregators may not read the channel
+type SkipHours struct {
+	Hours []int
+	skipList
+}
+
+func NewSkipHours() *SkipHours {
+	s := SkipHours{skipList: newSkipList("skipHours", "hour")}
+	s.parse = s.parseHour
+
+	return &s
+}
+
+func NewSkipHoursExt(manager extension.Manager) *SkipHours {
+	s := NewSkipHours()
+	s.Extension = extension.InitExtension("skiphours", manager)
+
+	return s
+}
+
+func (s *SkipHours) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if s.depth.IsRoot() {
+		s.Hours = nil
+	}
+
+	return s.skipList.ProcessStartElement(el)
+}
+
+func (s *SkipHours) parseHour(value string) xmlutils.ParserError {
+	hour, err := strconv.Atoi(value)
+	if err != nil || hour < 0 || hour > 23 {
+		return xmlutils.NewError(HourNotValid, fmt.Sprintf("hour '%s' should be between 0 and 23", value))
+	}
+
+	s.Hours = append(s.Hours, hour)
+
+	return nil
+}
+
+// Has reports whether hour is to be skipped
+func (s *SkipHours) Has(hour int) bool {
+	for _, h := range s.Hours {
+		if h == hour {
+			return true
+		}
+	}
+	return false
+}
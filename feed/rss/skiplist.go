@@ -0,0 +1,89 @@
+package rss
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/jloup/utils"
+	"github.com/jloup/xml/feed/extension"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// skipList parses the skipHours and skipDays elements: a list of child
+// elements named child whose values are handed to parse
+type skipList struct {
+	name  string
+	child string
+	parse func(value string) xmlutils.ParserError
+
+	text    string
+	inChild bool
+	errors  []xmlutils.ParserError
+
+	Extension extension.VisitorExtension
+	Parent    xmlutils.Visitor
+	depth     xmlutils.DepthWatcher
+}
+
+func newSkipList(name, child string) skipList {
+	return skipList{name: name, child: child, depth: xmlutils.NewDepthWatcher()}
+}
+
+func (s *skipList) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if s.depth.IsRoot() {
+		s.Extension = extension.InitExtension(el.Path, s.Extension.Manager)
+		s.errors = nil
+		for _, attr := range el.Attr {
+			s.Extension.ProcessAttr(attr, s)
+		}
+
+	} else {
+		switch el.Name.Space {
+		case "":
+			if el.Name.Local == s.child && s.depth.Level == 1 {
+				s.inChild = true
+				s.text = ""
+			}
+		default:
+			return s.Extension.ProcessElement(el, s)
+		}
+	}
+
+	s.depth.Down()
+
+	return s, nil
+}
+
+func (s *skipList) ProcessEndElement(el xml.EndElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if s.inChild {
+		s.inChild = false
+		if err := s.parse(strings.TrimSpace(s.text)); err != nil {
+			s.errors = append(s.errors, err)
+		}
+	}
+
+	if s.depth.Up() == xmlutils.RootLevel {
+		return s.Parent, s.validate()
+	}
+
+	return s, nil
+}
+
+func (s *skipList) ProcessCharData(el xml.CharData) (xmlutils.Visitor, xmlutils.ParserError) {
+	if s.inChild {
+		s.text += string(el)
+	}
+
+	return s, nil
+}
+
+func (s *skipList) validate() xmlutils.ParserError {
+	error := utils.NewErrorAggregator()
+
+	for _, err := range s.errors {
+		error.NewError(xmlutils.NewError(err.Flag(), s.name+"'s "+err.Msg()))
+	}
+	s.Extension.Validate(&error)
+
+	return error.ErrorObject()
+}
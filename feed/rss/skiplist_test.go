@@ -0,0 +1,123 @@
+package rss
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func NewTestSkipHours(hours ...int) *SkipHours {
+	s := NewSkipHours()
+	s.Hours = hours
+
+	return s
+}
+
+func NewTestSkipDays(days ...time.Weekday) *SkipDays {
+	s := NewSkipDays()
+	s.Days = days
+
+	return s
+}
+
+type testSkipList struct {
+	XML           string
+	ExpectedError xmlutils.ParserError
+	Expected      xmlutils.Visitor
+}
+
+func testSkipListValidator(actual xmlutils.Visitor, expected xmlutils.Visitor) error {
+	switch s1 := actual.(type) {
+	case *SkipHours:
+		s2 := expected.(*SkipHours)
+		if fmt.Sprint(s1.Hours) != fmt.Sprint(s2.Hours) {
+			return fmt.Errorf("Hours are invalid '%v' (expected) vs '%v'", s2.Hours, s1.Hours)
+		}
+	case *SkipDays:
+		s2 := expected.(*SkipDays)
+		if fmt.Sprint(s1.Days) != fmt.Sprint(s2.Days) {
+			return fmt.Errorf("Days are invalid '%v' (expected) vs '%v'", s2.Days, s1.Days)
+		}
+	}
+
+	return nil
+}
+
+func _TestSkipListToTestVisitor(t testSkipList) xmlutils.TestVisitor {
+	testVisitor := xmlutils.TestVisitor{
+		XML:             t.XML,
+		ExpectedError:   nil,
+		ExpectedVisitor: t.Expected,
+		Validator:       testSkipListValidator,
+	}
+
+	switch t.Expected.(type) {
+	case *SkipHours:
+		testVisitor.VisitorConstructor = func() xmlutils.Visitor { return NewSkipHours() }
+	case *SkipDays:
+		testVisitor.VisitorConstructor = func() xmlutils.Visitor { return NewSkipDays() }
+	}
+
+	if t.ExpectedError != nil {
+		testVisitor.ExpectedError = t.ExpectedError
+	}
+
+	return testVisitor
+}
+
+func TestSkipListBasic(t *testing.T) {
+
+	var testdata = []testSkipList{
+		{`<skipHours><hour>0</hour><hour> 7 </hour><hour>23</hour></skipHours>`,
+			nil,
+			NewTestSkipHours(0, 7, 23),
+		},
+		{`<skipHours></skipHours>`,
+			nil,
+			NewTestSkipHours(),
+		},
+		{`<skipHours><hour>0</hour><hour>24</hour></skipHours>`,
+			xmlutils.NewError(HourNotValid, ""),
+			NewTestSkipHours(0),
+		},
+		{`<skipHours><hour>noon</hour></skipHours>`,
+			xmlutils.NewError(HourNotValid, ""),
+			NewTestSkipHours(),
+		},
+		{`<skipDays><day>Saturday</day><day>Sunday</day><day>Sunday</day></skipDays>`,
+			nil,
+			NewTestSkipDays(time.Saturday, time.Sunday),
+		},
+		{`<skipDays><day>Saturday</day><day>sunday</day></skipDays>`,
+			xmlutils.NewError(DayNotValid, ""),
+			NewTestSkipDays(time.Saturday),
+		},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, testskip := range testdata {
+		testcase := _TestSkipListToTestVisitor(testskip)
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
+
+func TestSkipListHas(t *testing.T) {
+	hours := NewTestSkipHours(0, 1, 2)
+	if !hours.Has(1) || hours.Has(3) {
+		t.Errorf("SkipHours.Has is invalid for %v", hours.Hours)
+	}
+
+	days := NewTestSkipDays(time.Saturday, time.Sunday)
+	if !days.Has(time.Sunday) || days.Has(time.Monday) {
+		t.Errorf("SkipDays.Has is invalid for %v", days.Days)
+	}
+}
@@ -22,7 +22,7 @@ func NewSource() *Source {
 	s := Source{depth: xmlutils.NewDepthWatcher()}
 
 	s.Url = xmlutils.NewElement("url", "", xmlutils.Nop)
-	s.Url.SetOccurence(xmlutils.NewOccurence("url", xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)))
+	s.Url.SetOccurence(xmlutils.NewOccurence("url", existsAndIsUnique))
 
 	return &s
 }
@@ -40,6 +40,7 @@ func (s *Source) reset() {
 
 func (s *Source) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	if s.depth.IsRoot() {
+		s.Extension = extension.InitExtension(el.Path, s.Extension.Manager)
 		s.reset()
 		for _, attr := range el.Attr {
 			switch attr.Name.Space {
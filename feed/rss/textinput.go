@@ -0,0 +1,134 @@
+package rss
+
+import (
+	"encoding/xml"
+
+	"github.com/jloup/utils"
+	"github.com/jloup/xml/feed/extension"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// TextInput is a text input box that can be displayed with the channel
+type TextInput struct {
+	Title       *BasicElement
+	Description *BasicElement
+	Name        *BasicElement
+	Link        *BasicElement
+
+	Extension extension.VisitorExtension
+	Parent    xmlutils.Visitor
+	depth     xmlutils.DepthWatcher
+}
+
+func NewTextInput() *TextInput {
+	t := TextInput{depth: xmlutils.NewDepthWatcher()}
+
+	t.Title = NewBasicElement()
+	t.Description = NewBasicElement()
+	t.Name = NewBasicElement()
+	t.Link = NewBasicElement()
+
+	t.init()
+
+	return &t
+}
+
+func NewTextInputExt(manager extension.Manager) *TextInput {
+	t := TextInput{depth: xmlutils.NewDepthWatcher()}
+
+	t.Title = NewBasicElementExt(manager)
+	t.Description = NewBasicElementExt(manager)
+	t.Name = NewBasicElementExt(manager)
+	t.Link = NewBasicElementExt(manager)
+
+	t.init()
+	t.Extension = extension.InitExtension("textinput", manager)
+
+	return &t
+}
+
+func (t *TextInput) init() {
+	t.Title.Content = xmlutils.NewElement("title", "", xmlutils.Nop)
+	t.Title.Content.SetOccurence(xmlutils.NewOccurence("title", existsAndIsUnique))
+
+	t.Description.Content = xmlutils.NewElement("description", "", xmlutils.Nop)
+	t.Description.Content.SetOccurence(xmlutils.NewOccurence("description", existsAndIsUnique))
+
+	t.Name.Content = xmlutils.NewElement("name", "", xmlutils.Nop)
+	t.Name.Content.SetOccurence(xmlutils.NewOccurence("name", existsAndIsUnique))
+
+	t.Link.Content = xmlutils.NewElement("link", "", IsValidIRI)
+	t.Link.Content.SetOccurence(xmlutils.NewOccurence("link", existsAndIsUnique))
+
+	t.Title.Parent = t
+	t.Description.Parent = t
+	t.Name.Parent = t
+	t.Link.Parent = t
+}
+
+func (t *TextInput) reset() {
+	t.Title.Content.Reset()
+	t.Description.Content.Reset()
+	t.Name.Content.Reset()
+	t.Link.Content.Reset()
+}
+
+func (t *TextInput) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if t.depth.IsRoot() {
+		t.Extension = extension.InitExtension(el.Path, t.Extension.Manager)
+		t.reset()
+		for _, attr := range el.Attr {
+			t.Extension.ProcessAttr(attr, t)
+		}
+
+	}
+
+	switch el.Name.Space {
+	case "":
+		switch el.Name.Local {
+		case "title":
+			t.Title.Content.IncOccurence()
+			t.Title.Reset()
+			return t.Title.ProcessStartElement(el)
+		case "description":
+			t.Description.Content.IncOccurence()
+			t.Description.Reset()
+			return t.Description.ProcessStartElement(el)
+		case "name":
+			t.Name.Content.IncOccurence()
+			t.Name.Reset()
+			return t.Name.ProcessStartElement(el)
+		case "link":
+			t.Link.Content.IncOccurence()
+			t.Link.Reset()
+			return t.Link.ProcessStartElement(el)
+		}
+	default:
+		return t.Extension.ProcessElement(el, t)
+	}
+	t.depth.Down()
+
+	return t, nil
+}
+
+func (t *TextInput) ProcessEndElement(el xml.EndElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	if t.depth.Up() == xmlutils.RootLevel {
+
+		return t.Parent, t.validate()
+	}
+
+	return t, nil
+}
+
+func (t *TextInput) ProcessCharData(el xml.CharData) (xmlutils.Visitor, xmlutils.ParserError) {
+	return t, nil
+}
+
+func (t *TextInput) validate() xmlutils.ParserError {
+	error := utils.NewErrorAggregator()
+
+	xmlutils.ValidateElements("textInput", &error, t.Title.Content, t.Description.Content, t.Name.Content, t.Link.Content)
+	t.Extension.Validate(&error)
+
+	return error.ErrorObject()
+}
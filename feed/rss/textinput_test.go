@@ -0,0 +1,127 @@
+package rss
+
+import (
+	"fmt"
+	"testing"
+
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func NewTestTextInput(title, description, name, link string) *TextInput {
+	t := NewTextInput()
+
+	t.Title.Content.Value = title
+	t.Description.Content.Value = description
+	t.Name.Content.Value = name
+	t.Link.Content.Value = link
+
+	return t
+}
+
+type testTextInput struct {
+	XML               string
+	ExpectedError     xmlutils.ParserError
+	ExpectedTextInput *TextInput
+}
+
+func testTextInputValidator(actual xmlutils.Visitor, expected xmlutils.Visitor) error {
+	t1 := actual.(*TextInput)
+	t2 := expected.(*TextInput)
+
+	if t1.Title.String() != t2.Title.String() {
+		return fmt.Errorf("Title is invalid '%s' (expected) vs '%s'", t2.Title.String(), t1.Title.String())
+	}
+
+	if t1.Description.String() != t2.Description.String() {
+		return fmt.Errorf("Description is invalid '%s' (expected) vs '%s'", t2.Description.String(), t1.Description.String())
+	}
+
+	if t1.Name.String() != t2.Name.String() {
+		return fmt.Errorf("Name is invalid '%s' (expected) vs '%s'", t2.Name.String(), t1.Name.String())
+	}
+
+	if t1.Link.String() != t2.Link.String() {
+		return fmt.Errorf("Link is invalid '%s' (expected) vs '%s'", t2.Link.String(), t1.Link.String())
+	}
+
+	return nil
+}
+
+func testTextInputConstructor() xmlutils.Visitor {
+	return NewTextInput()
+}
+
+func _TestTextInputToTestVisitor(t testTextInput) xmlutils.TestVisitor {
+	testVisitor := xmlutils.TestVisitor{
+		XML:                t.XML,
+		ExpectedError:      nil,
+		ExpectedVisitor:    t.ExpectedTextInput,
+		VisitorConstructor: testTextInputConstructor,
+		Validator:          testTextInputValidator,
+	}
+
+	if t.ExpectedError != nil {
+		testVisitor.ExpectedError = t.ExpectedError
+	}
+
+	return testVisitor
+}
+
+func TestTextInputBasic(t *testing.T) {
+
+	var testdata = []testTextInput{
+		{`
+         <textInput>
+           <title>Search</title>
+           <description>Search the archives</description>
+           <name>q</name>
+           <link>http://example.org/search</link>
+         </textInput>`,
+			nil,
+			NewTestTextInput("Search", "Search the archives", "q", "http://example.org/search"),
+		},
+		{`
+         <textInput>
+           <title>Search</title>
+           <description>Search the archives</description>
+           <link>http://example.org/search</link>
+         </textInput>`,
+			xmlutils.NewError(MissingAttribute, ""),
+			NewTestTextInput("Search", "Search the archives", "", "http://example.org/search"),
+		},
+		{`
+         <textInput>
+           <title>Search</title>
+           <title>Search again</title>
+           <description>Search the archives</description>
+           <name>q</name>
+           <link>http://example.org/search</link>
+         </textInput>`,
+			xmlutils.NewError(AttributeDuplicated, ""),
+			NewTestTextInput("Search again", "Search the archives", "q", "http://example.org/search"),
+		},
+		{`
+         <textInput>
+           <title>Search</title>
+           <description>Search the archives</description>
+           <name>q</name>
+           <link>http://%%%example.org/search</link>
+         </textInput>`,
+			xmlutils.NewError(IriNotValid, ""),
+			NewTestTextInput("Search", "Search the archives", "q", "http://%%%example.org/search"),
+		},
+	}
+
+	nbErrors := 0
+	len := len(testdata)
+	for _, testtextinput := range testdata {
+		testcase := _TestTextInputToTestVisitor(testtextinput)
+
+		if err := testcase.CheckTestCase(); err != nil {
+			t.Errorf("FAIL\n%s\nXML:\n %s\n", err, testcase.XML)
+			nbErrors++
+		}
+	}
+
+	t.Logf("PASS RATIO = %v/%v\n", len-nbErrors, len)
+}
@@ -0,0 +1,36 @@
+package rss
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jloup/xml/feed/extension"
+)
+
+// Ttl is the number of minutes the channel can be cached before refreshing
+type Ttl struct {
+	*BasicElement
+}
+
+func NewTtl() *Ttl {
+	return &Ttl{NewBasicElement()}
+}
+
+func NewTtlExt(manager extension.Manager) *Ttl {
+	return &Ttl{NewBasicElementExt(manager)}
+}
+
+// Minutes returns the ttl value; ok is false when it is missing or not valid
+func (t *Ttl) Minutes() (minutes int, ok bool) {
+	minutes, err := strconv.Atoi(t.String())
+	if err != nil || minutes < 0 {
+		return 0, false
+	}
+	return minutes, true
+}
+
+// Duration returns the ttl as a time.Duration, 0 when it is missing or not valid
+func (t *Ttl) Duration() time.Duration {
+	minutes, _ := t.Minutes()
+	return time.Duration(minutes) * time.Minute
+}
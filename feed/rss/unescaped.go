@@ -16,6 +16,7 @@ type UnescapedContent struct {
 	Encoder   *xml.Encoder
 	Extension extension.VisitorExtension
 	depth     xmlutils.DepthWatcher
+	limits    *xmlutils.Limits
 	Parent    xmlutils.Visitor
 }
 
@@ -45,16 +46,21 @@ func (u *UnescapedContent) EncodeXHTMLToken(t xml.Token) error {
 	return err
 }
 
-func (u *UnescapedContent) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
-	if u.depth.IsRoot() {
-		u.name = el.Name
-		u.Extension = extension.InitExtension(u.name.Local, u.Extension.Manager)
+// start is called by the parent on the opening tag of the element holding
+// the content; the following child elements are part of the content
+func (u *UnescapedContent) start(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
+	u.name = el.Name
+	u.limits = el.Limits
+	u.Extension = extension.InitExtension(el.Path, u.Extension.Manager, "unescaped")
 
-		for _, attr := range el.Attr {
-			u.Extension.ProcessAttr(attr, u)
-		}
+	for _, attr := range el.Attr {
+		u.Extension.ProcessAttr(attr, u)
 	}
 
+	return u, nil
+}
+
+func (u *UnescapedContent) ProcessStartElement(el xmlutils.StartElement) (xmlutils.Visitor, xmlutils.ParserError) {
 	err := utils.NewErrorAggregator()
 
 	u.depth.Down()
@@ -63,6 +69,10 @@ func (u *UnescapedContent) ProcessStartElement(el xmlutils.StartElement) (xmluti
 		err.NewError(xmlutils.NewError(XHTMLEncodeToStringError, "cannot encode XHTML"))
 	}
 
+	if error := u.limits.CheckContentLength(u.Content.Len()); error != nil {
+		err.NewError(error)
+	}
+
 	return u, err.ErrorObject()
 }
 
@@ -92,6 +102,10 @@ func (u *UnescapedContent) ProcessCharData(el xml.CharData) (xmlutils.Visitor, x
 		if _, err := u.Content.Write(el); err != nil {
 			return u, xmlutils.NewError(CannotFlush, "cannot flush content")
 		}
+
+		if err := u.limits.CheckContentLength(u.Content.Len()); err != nil {
+			return u, err
+		}
 	}
 
 	return u, nil
@@ -1,7 +1,85 @@
 package rss
 
-import xmlutils "github.com/jloup/xml/utils"
+import (
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+var (
+	IsValidIRI    = xmlutils.IsValidIri(IriNotValid)
+	IsAbsoluteIRI = xmlutils.IsValidAbsoluteIri(IriNotAbsolute)
+	IsValidNumber = xmlutils.IsValidNumber(NotPositiveNumber)
+	isMIME        = xmlutils.IsValidMIME(IsNotMIME)
+
+	// occurence validators only read the occurence they check: elements share
+	// them rather than allocate their own
+	isUnique          = xmlutils.UniqueValidator(AttributeDuplicated)
+	existsAndIsUnique = xmlutils.ExistsAndUniqueValidator(MissingAttribute, AttributeDuplicated)
+)
+
+// IsValidMIME checks s is a type/subtype media type, with optional parameters
+func IsValidMIME(name, s string) xmlutils.ParserError {
+	if err := isMIME(name, s); err != nil {
+		return err
+	}
+
+	if mediatype, _, _ := mime.ParseMediaType(s); !strings.Contains(strings.Trim(mediatype, "/"), "/") {
+		return xmlutils.NewError(IsNotMIME, fmt.Sprintf("%s '%s' is not a type/subtype media type", name, s))
+	}
+	return nil
+}
+
+// maximum and default dimensions of a channel image, in pixels
+const (
+	MaxImageWidth      = 144
+	MaxImageHeight     = 400
+	DefaultImageWidth  = 88
+	DefaultImageHeight = 31
+)
+
+func isValidImageDimension(max int) func(string, string) xmlutils.ParserError {
+	return func(name, s string) xmlutils.ParserError {
+		if err := IsValidNumber(name, s); err != nil {
+			return err
+		}
+
+		if n, _ := strconv.Atoi(s); n > max {
+			return xmlutils.NewError(ImageTooLarge, fmt.Sprintf("%s '%s' should not be greater than %v", name, s, max))
+		}
+		return nil
+	}
+}
 
 var (
-	IsValidIRI = xmlutils.IsValidIri(IriNotValid)
+	IsValidImageWidth  = isValidImageDimension(MaxImageWidth)
+	IsValidImageHeight = isValidImageDimension(MaxImageHeight)
 )
+
+func IsValidLength(name, s string) xmlutils.ParserError {
+	if n, err := strconv.ParseInt(s, 10, 64); err != nil || n < 0 {
+		return xmlutils.NewError(NotPositiveNumber, fmt.Sprintf("%s '%s' is not a valid number of bytes", name, s))
+	}
+
+	return nil
+}
+
+func IsValidPort(name, s string) xmlutils.ParserError {
+	if port, err := strconv.Atoi(s); err != nil || port < 1 || port > 65535 {
+		return xmlutils.NewError(PortNotValid, fmt.Sprintf("%s '%s' is not a valid port number", name, s))
+	}
+
+	return nil
+}
+
+func IsValidCloudProtocol(name, s string) xmlutils.ParserError {
+	switch s {
+	case "xml-rpc", "soap", "http-post":
+		return nil
+	}
+
+	return xmlutils.NewError(CloudProtocolNotValid, fmt.Sprintf("%s '%s' should be one of xml-rpc, soap or http-post", name, s))
+}
@@ -0,0 +1,247 @@
+// Package schedule advises when a feed should be fetched again. The advice
+// combines the publisher hints (RSS ttl, skipHours and skipDays, the
+// sy:updatePeriod and sy:updateFrequency extension), the HTTP cache headers of
+// the last fetch and the dates of the entries observed so far.
+//
+// RSS channels must be parsed with the sy extension registered in the
+// extension Manager for the syndication hints to be taken into account.
+package schedule
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/sy"
+)
+
+// Policy bounds the advised interval
+type Policy struct {
+	// Min and Max bound the interval between two fetches, publisher hints
+	// included. A zero value disables the bound.
+	Min time.Duration
+	Max time.Duration
+	// Default is used when the entry dates do not allow to estimate the
+	// publishing rate
+	Default time.Duration
+}
+
+// DefaultPolicy fetches a feed at least once a day and at most every 15 minutes
+var DefaultPolicy = Policy{Min: 15 * time.Minute, Max: 24 * time.Hour, Default: time.Hour}
+
+// Input gathers what is known about a feed. Every field but Now is optional.
+type Input struct {
+	// Now is the time of the last fetch
+	Now     time.Time
+	Channel *rss.Channel
+	Feed    *atom.Feed
+	// Header holds the HTTP response headers of the last fetch
+	Header http.Header
+	// History holds the entry dates observed in past parses. Dates of the
+	// entries of Channel and Feed are added to it.
+	History []time.Time
+}
+
+// Decision is the result of Advise
+type Decision struct {
+	Next     time.Time
+	Interval time.Duration
+	// Reasons explains, step by step, how Interval and Next were computed
+	Reasons []string
+}
+
+func (d *Decision) explain(format string, a ...interface{}) {
+	d.Reasons = append(d.Reasons, fmt.Sprintf(format, a...))
+}
+
+// Advise computes the next fetch time.
+//
+// The interval starts from the median gap between entry dates, or
+// policy.Default when less than two distinct dates are known. Publisher hints
+// and HTTP cache headers can only lengthen it, then it is bounded by policy.Min
+// and policy.Max. Finally, Next is moved out of the hours and days the RSS
+// channel asks to skip.
+func Advise(in Input, policy Policy) Decision {
+	d := Decision{}
+
+	if gap, n, ok := medianGap(entryDates(in)); ok {
+		d.Interval = gap
+		d.explain("history: median gap between %d entry dates is %s", n, gap)
+	} else {
+		d.Interval = policy.Default
+		d.explain("history: not enough entry dates, default interval is %s", policy.Default)
+	}
+
+	if in.Channel != nil {
+		if minutes, ok := in.Channel.Ttl.Minutes(); ok {
+			d.lengthen(time.Duration(minutes)*time.Minute, "rss ttl")
+		}
+
+		if interval, ok := sy.GetUpdateInterval(in.Channel); ok {
+			d.lengthen(interval, "sy:updatePeriod/sy:updateFrequency")
+		}
+	}
+
+	if in.Header != nil {
+		if maxAge, ok := cacheMaxAge(in.Header); ok {
+			d.lengthen(maxAge, "http Cache-Control max-age")
+		} else if expires, ok := cacheExpires(in.Header, in.Now); ok {
+			d.lengthen(expires, "http Expires")
+		}
+
+		if retry, ok := retryAfter(in.Header, in.Now); ok {
+			d.lengthen(retry, "http Retry-After")
+		}
+	}
+
+	if policy.Min > 0 && d.Interval < policy.Min {
+		d.Interval = policy.Min
+		d.explain("policy: interval raised to minimum %s", policy.Min)
+	}
+
+	if policy.Max > 0 && d.Interval > policy.Max {
+		d.Interval = policy.Max
+		d.explain("policy: interval lowered to maximum %s", policy.Max)
+	}
+
+	d.Next = in.Now.Add(d.Interval)
+
+	if in.Channel != nil {
+		d.skip(in.Channel.SkipHours, in.Channel.SkipDays)
+	}
+
+	return d
+}
+
+func (d *Decision) lengthen(hint time.Duration, source string) {
+	if hint > d.Interval {
+		d.explain("%s: interval raised from %s to %s", source, d.Interval, hint)
+		d.Interval = hint
+	} else {
+		d.explain("%s: %s does not exceed interval %s", source, hint, d.Interval)
+	}
+}
+
+// skip moves Next to the first hour allowed by skipHours and skipDays. Both
+// are expressed in GMT.
+func (d *Decision) skip(hours *rss.SkipHours, days *rss.SkipDays) {
+	if len(hours.Hours) == 0 && len(days.Days) == 0 {
+		return
+	}
+
+	next := d.Next.UTC()
+	for i := 0; i < 7*24; i++ {
+		if !hours.Has(next.Hour()) && !days.Has(next.Weekday()) {
+			if i > 0 {
+				d.explain("rss skipHours/skipDays: next fetch moved from %s to %s", d.Next.UTC().Format(time.RFC3339), next.Format(time.RFC3339))
+				d.Next = next
+			}
+			return
+		}
+		next = next.Truncate(time.Hour).Add(time.Hour)
+	}
+
+	d.explain("rss skipHours/skipDays: every hour of the week is skipped, hint ignored")
+}
+
+func entryDates(in Input) []time.Time {
+	dates := append([]time.Time{}, in.History...)
+
+	if in.Channel != nil {
+		for _, item := range in.Channel.Items {
+			dates = append(dates, item.PubDate.Time)
+		}
+	}
+
+	if in.Feed != nil {
+		for _, entry := range in.Feed.Entries {
+			dates = append(dates, entry.Updated.Time)
+		}
+	}
+
+	return dates
+}
+
+// medianGap returns the median gap between the distinct non-zero dates; n is
+// the number of such dates
+func medianGap(dates []time.Time) (gap time.Duration, n int, ok bool) {
+	seen := make(map[int64]bool)
+	var unique []time.Time
+	for _, date := range dates {
+		if date.IsZero() || seen[date.UnixNano()] {
+			continue
+		}
+		seen[date.UnixNano()] = true
+		unique = append(unique, date)
+	}
+
+	if len(unique) < 2 {
+		return 0, len(unique), false
+	}
+
+	sort.Slice(unique, func(i, j int) bool { return unique[i].Before(unique[j]) })
+
+	gaps := make([]time.Duration, len(unique)-1)
+	for i := 1; i < len(unique); i++ {
+		gaps[i-1] = unique[i].Sub(unique[i-1])
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+
+	return gaps[len(gaps)/2], len(unique), true
+}
+
+func cacheMaxAge(header http.Header) (time.Duration, bool) {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(strings.ToLower(directive))
+		if !strings.HasPrefix(directive, "max-age=") {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+		if err != nil || seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	return 0, false
+}
+
+func cacheExpires(header http.Header, now time.Time) (time.Duration, bool) {
+	expires, err := http.ParseTime(header.Get("Expires"))
+	if err != nil {
+		return 0, false
+	}
+
+	// Expires is relative to the server clock
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		now = date
+	}
+
+	if !expires.After(now) {
+		return 0, false
+	}
+	return expires.Sub(now), true
+}
+
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,206 @@
+package schedule
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/sy"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+// 2024-01-06 is a Saturday
+var testNow = time.Date(2024, time.January, 6, 10, 0, 0, 0, time.UTC)
+
+func parseRssChannel(t *testing.T, s string) *rss.Channel {
+	manager := extension.Manager{}
+	sy.AddToManager(&manager)
+
+	c := rss.NewChannelExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(`<channel xmlns:sy="http://purl.org/rss/1.0/modules/syndication/">`+s+`</channel>`), c, &checker, 0); err != nil {
+		t.Fatalf("cannot parse channel: %s", err)
+	}
+
+	return c
+}
+
+func parseAtomFeed(t *testing.T, s string) *atom.Feed {
+	f := atom.NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom">`+s+`</feed>`), f, &checker, 0); err != nil {
+		t.Fatalf("cannot parse feed: %s", err)
+	}
+
+	return f
+}
+
+func header(kv ...string) http.Header {
+	h := http.Header{}
+	for i := 0; i < len(kv); i += 2 {
+		h.Set(kv[i], kv[i+1])
+	}
+	return h
+}
+
+const testItems = `
+<item><pubDate>Sat, 06 Jan 2024 06:00:00 GMT</pubDate></item>
+<item><pubDate>Sat, 06 Jan 2024 08:00:00 GMT</pubDate></item>
+<item><pubDate>Sat, 06 Jan 2024 09:00:00 GMT</pubDate></item>
+<item><pubDate>Sat, 06 Jan 2024 09:00:00 GMT</pubDate></item>
+<item><pubDate>Sat, 06 Jan 2024 11:00:00 GMT</pubDate></item>`
+
+type testAdvise struct {
+	Name             string
+	Input            func(t *testing.T) Input
+	Policy           Policy
+	ExpectedInterval time.Duration
+	ExpectedNext     time.Time
+}
+
+func TestAdvise(t *testing.T) {
+	var testdata = []testAdvise{
+		{"no data",
+			func(t *testing.T) Input { return Input{Now: testNow} },
+			DefaultPolicy,
+			time.Hour,
+			testNow.Add(time.Hour),
+		},
+		{"rss history",
+			func(t *testing.T) Input { return Input{Now: testNow, Channel: parseRssChannel(t, testItems)} },
+			DefaultPolicy,
+			2 * time.Hour,
+			testNow.Add(2 * time.Hour),
+		},
+		{"atom history merged with past dates",
+			func(t *testing.T) Input {
+				return Input{
+					Now:     testNow,
+					Feed:    parseAtomFeed(t, `<entry><updated>2024-01-06T09:00:00Z</updated></entry>`),
+					History: []time.Time{testNow.Add(-7 * time.Hour), testNow.Add(-4 * time.Hour)},
+				}
+			},
+			DefaultPolicy,
+			3 * time.Hour,
+			testNow.Add(3 * time.Hour),
+		},
+		{"ttl",
+			func(t *testing.T) Input {
+				return Input{Now: testNow, Channel: parseRssChannel(t, `<ttl>180</ttl>`+testItems)}
+			},
+			DefaultPolicy,
+			3 * time.Hour,
+			testNow.Add(3 * time.Hour),
+		},
+		{"sy",
+			func(t *testing.T) Input {
+				return Input{Now: testNow, Channel: parseRssChannel(t, `<sy:updatePeriod>daily</sy:updatePeriod><sy:updateFrequency>4</sy:updateFrequency>`)}
+			},
+			DefaultPolicy,
+			6 * time.Hour,
+			testNow.Add(6 * time.Hour),
+		},
+		{"sy shorter than history",
+			func(t *testing.T) Input {
+				return Input{Now: testNow, Channel: parseRssChannel(t, `<sy:updatePeriod>hourly</sy:updatePeriod>`+testItems)}
+			},
+			DefaultPolicy,
+			2 * time.Hour,
+			testNow.Add(2 * time.Hour),
+		},
+		{"cache-control",
+			func(t *testing.T) Input {
+				return Input{Now: testNow, Header: header("Cache-Control", "public, max-age=7200", "Expires", "Sat, 06 Jan 2024 20:00:00 GMT")}
+			},
+			DefaultPolicy,
+			2 * time.Hour,
+			testNow.Add(2 * time.Hour),
+		},
+		{"expires",
+			func(t *testing.T) Input {
+				return Input{Now: testNow, Header: header("Date", "Sat, 06 Jan 2024 09:00:00 GMT", "Expires", "Sat, 06 Jan 2024 13:00:00 GMT")}
+			},
+			DefaultPolicy,
+			4 * time.Hour,
+			testNow.Add(4 * time.Hour),
+		},
+		{"retry-after",
+			func(t *testing.T) Input { return Input{Now: testNow, Header: header("Retry-After", "18000")} },
+			DefaultPolicy,
+			5 * time.Hour,
+			testNow.Add(5 * time.Hour),
+		},
+		{"policy max",
+			func(t *testing.T) Input {
+				return Input{Now: testNow, Channel: parseRssChannel(t, `<sy:updatePeriod>weekly</sy:updatePeriod>`)}
+			},
+			DefaultPolicy,
+			24 * time.Hour,
+			testNow.Add(24 * time.Hour),
+		},
+		{"policy min",
+			func(t *testing.T) Input {
+				return Input{Now: testNow, History: []time.Time{testNow, testNow.Add(-time.Minute), testNow.Add(-2 * time.Minute)}}
+			},
+			DefaultPolicy,
+			15 * time.Minute,
+			testNow.Add(15 * time.Minute),
+		},
+		{"skip hours",
+			func(t *testing.T) Input {
+				return Input{Now: testNow, Channel: parseRssChannel(t, `<skipHours><hour>11</hour><hour>12</hour></skipHours>`)}
+			},
+			DefaultPolicy,
+			time.Hour,
+			time.Date(2024, time.January, 6, 13, 0, 0, 0, time.UTC),
+		},
+		{"skip days",
+			func(t *testing.T) Input {
+				return Input{Now: testNow, Channel: parseRssChannel(t, `<skipDays><day>Saturday</day><day>Sunday</day></skipDays>`)}
+			},
+			DefaultPolicy,
+			time.Hour,
+			time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range testdata {
+		d := Advise(test.Input(t), test.Policy)
+
+		if d.Interval != test.ExpectedInterval {
+			t.Errorf("%s: interval should be %s, got %s\n%s", test.Name, test.ExpectedInterval, d.Interval, strings.Join(d.Reasons, "\n"))
+		}
+
+		if !d.Next.Equal(test.ExpectedNext) {
+			t.Errorf("%s: next should be %s, got %s\n%s", test.Name, test.ExpectedNext, d.Next, strings.Join(d.Reasons, "\n"))
+		}
+
+		if len(d.Reasons) == 0 {
+			t.Errorf("%s: decision should be explained", test.Name)
+		}
+	}
+}
+
+func TestAdviseAllHoursSkipped(t *testing.T) {
+	var hours []string
+	for h := 0; h < 24; h++ {
+		hours = append(hours, "<hour>"+strconv.Itoa(h)+"</hour>")
+	}
+
+	c := parseRssChannel(t, `<skipHours>`+strings.Join(hours, "")+`</skipHours>`)
+	if len(c.SkipHours.Hours) != 24 {
+		t.Fatalf("channel should skip 24 hours, got %v", c.SkipHours.Hours)
+	}
+
+	d := Advise(Input{Now: testNow, Channel: c}, DefaultPolicy)
+	if !d.Next.Equal(testNow.Add(time.Hour)) {
+		t.Errorf("unsatisfiable skipHours should be ignored, got %s", d.Next)
+	}
+}
@@ -0,0 +1,221 @@
+package feed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/person"
+	"github.com/jloup/xml/feed/rss"
+)
+
+// BasicSource is the metadata of the feed an entry comes from. In
+// BasicEntryBlock, it is only set for entries copied from another feed:
+// Atom:entry:source, or RSS:item:source which only has a title and the URL of
+// the original feed, kept as Self.
+type BasicSource struct {
+	// Atom:source:id
+	Id string
+	// Atom:source:title | RSS:item:source
+	Title string
+	// Atom:source:subtitle
+	Subtitle string
+	// Atom:source:link (first alternate)
+	Link string
+	// Atom:source:link (first self) | RSS:item:source:url
+	Self string
+	// Atom:source:link (all, in document order)
+	Links BasicLinks
+	// Atom:source:updated
+	Updated time.Time
+	// Atom:source:icon
+	Icon string
+	// Atom:source:logo
+	Logo string
+	// Atom:source:rights
+	Rights string
+	// Atom:source:author
+	Authors []person.Person
+}
+
+// IsZero reports whether s describes no feed
+func (s BasicSource) IsZero() bool {
+	return s.Id == "" && s.Title == "" && s.Link == "" && s.Self == ""
+}
+
+// SourceFromAtomSource reads the atom:source of an entry
+func SourceFromAtomSource(s *atom.Source) BasicSource {
+	source := BasicSource{
+		Id:       s.Id.String(),
+		Title:    s.Title.String(),
+		Subtitle: s.Subtitle.String(),
+		Links:    atomLinks(s.Links),
+		Updated:  s.Updated.Time,
+		Icon:     s.Icon.Iri.String(),
+		Logo:     s.Logo.Iri.String(),
+		Rights:   s.Rights.String(),
+		Authors:  person.FromAtomPersons(s.Authors),
+	}
+
+	source.Link = source.Links.First("alternate")
+	source.Self = source.Links.First("self")
+
+	return source
+}
+
+// SourceFromAtomFeed describes f, as the origin of its entries
+func SourceFromAtomFeed(f *atom.Feed) BasicSource {
+	source := BasicSource{
+		Id:       f.Id.String(),
+		Title:    f.Title.String(),
+		Subtitle: f.Subtitle.String(),
+		Links:    atomLinks(f.Links),
+		Updated:  f.Updated.Time,
+		Icon:     f.Icon.Iri.String(),
+		Logo:     f.Logo.Iri.String(),
+		Rights:   f.Rights.String(),
+		Authors:  person.FromAtomPersons(f.Authors),
+	}
+
+	source.Link = source.Links.First("alternate")
+	source.Self = source.Links.First("self")
+
+	return source
+}
+
+// SourceFromRssChannel describes c, as the origin of its items. A channel does
+// not know the URL it is published at: set Self when known. Updated is
+// lastBuildDate, then pubDate.
+func SourceFromRssChannel(c *rss.Channel) BasicSource {
+	source := BasicSource{
+		Title:    c.Title.String(),
+		Subtitle: c.Description.String(),
+		Link:     c.Link.String(),
+		Updated:  c.LastBuildDate.Time,
+		Logo:     c.Image.Url.String(),
+		Rights:   c.Copyright.String(),
+		Authors:  person.RssChannelAuthors(c),
+	}
+
+	if source.Updated.IsZero() {
+		source.Updated = c.PubDate.Time
+	}
+
+	if source.Link != "" {
+		source.Links = BasicLinks{{Rel: "alternate", Href: source.Link}}
+	}
+
+	return source
+}
+
+// AtomSource is an atom:source element, to be encoded with encoding/xml within
+// an Atom entry
+type AtomSource struct {
+	XMLName  xml.Name     `xml:"source"`
+	Id       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Subtitle string       `xml:"subtitle,omitempty"`
+	Updated  string       `xml:"updated"`
+	Icon     string       `xml:"icon,omitempty"`
+	Logo     string       `xml:"logo,omitempty"`
+	Rights   string       `xml:"rights,omitempty"`
+	Links    []AtomLink   `xml:"link"`
+	Authors  []AtomPerson `xml:"author"`
+}
+
+// AtomLink is an atom:link element
+type AtomLink struct {
+	Rel   string `xml:"rel,attr,omitempty"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+// AtomPerson is an atom:author or atom:contributor element
+type AtomPerson struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email,omitempty"`
+	Uri   string `xml:"uri,omitempty"`
+}
+
+// NewAtomSource builds the atom:source of an entry re-published out of the
+// feed s describes. An entry which already has a source (see
+// BasicSource.IsZero) must keep it rather than get the one of the feed it has
+// been read from.
+//
+// The result passes the validators of the atom package, which require id,
+// title and updated: id is the first absolute IRI of Id, Self and Link (a
+// "urn:sha256:" IRI derived from every field of s but Updated otherwise), title
+// falls back to id and updated to fallback (e.g. the date of the entry).
+// Authors without name are dropped. Sources with none of those fields all get
+// the same id: callers should set Id on them.
+func NewAtomSource(s BasicSource, fallback time.Time) AtomSource {
+	source := AtomSource{
+		Id:       AbsoluteIRI(s.key(), s.Id, s.Self, s.Link),
+		Title:    s.Title,
+		Subtitle: s.Subtitle,
+		Updated:  s.Updated.UTC().Format(time.RFC3339),
+		Icon:     s.Icon,
+		Logo:     s.Logo,
+		Rights:   s.Rights,
+	}
+
+	if source.Title == "" {
+		source.Title = source.Id
+	}
+
+	if s.Updated.IsZero() {
+		source.Updated = fallback.UTC().Format(time.RFC3339)
+	}
+
+	links := s.Links
+	if s.Link != "" && links.First("alternate") == "" {
+		links = append(links, BasicLink{Rel: "alternate", Href: s.Link})
+	}
+	if s.Self != "" && links.First("self") == "" {
+		links = append(links, BasicLink{Rel: "self", Href: s.Self})
+	}
+
+	for _, link := range links {
+		source.Links = append(source.Links, AtomLink{Rel: link.Rel, Href: link.Href, Type: link.Type, Title: link.Title})
+	}
+
+	for _, p := range s.Authors {
+		if p.Name != "" {
+			source.Authors = append(source.Authors, AtomPerson{Name: p.Name, Email: p.Email, Uri: p.Uri})
+		}
+	}
+
+	return source
+}
+
+// key identifies s by its fields but Updated, which changes with every
+// publication of the feed
+func (s BasicSource) key() string {
+	fields := []string{s.Id, s.Title, s.Subtitle, s.Link, s.Self, s.Icon, s.Logo, s.Rights}
+	for _, link := range s.Links {
+		fields = append(fields, link.Rel, link.Href)
+	}
+	for _, p := range s.Authors {
+		fields = append(fields, p.Name, p.Email, p.Uri)
+	}
+
+	return strings.Join(fields, "\n")
+}
+
+// AbsoluteIRI returns the first candidate which is an absolute IRI, as
+// required by Atom ids, or a "urn:sha256:" IRI derived from key
+func AbsoluteIRI(key string, candidates ...string) string {
+	for _, candidate := range candidates {
+		if u, err := url.Parse(candidate); err == nil && u.IsAbs() {
+			return candidate
+		}
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return "urn:sha256:" + hex.EncodeToString(sum[:])
+}
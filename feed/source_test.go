@@ -0,0 +1,131 @@
+package feed_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/person"
+	"github.com/jloup/xml/feed/rss"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+type testAtomEntry struct {
+	XMLName xml.Name         `xml:"http://www.w3.org/2005/Atom entry"`
+	Id      string           `xml:"id"`
+	Title   string           `xml:"title"`
+	Updated string           `xml:"updated"`
+	Content string           `xml:"content"`
+	Author  feed.AtomPerson  `xml:"author"`
+	Source  *feed.AtomSource `xml:"source"`
+}
+
+// checkAtomSource encodes source in an entry and checks it against the atom
+// validators
+func checkAtomSource(t *testing.T, source feed.AtomSource) *atom.Entry {
+	b, err := xml.Marshal(testAtomEntry{
+		Id:      "tag:example.org,2024:1",
+		Title:   "e",
+		Updated: "2024-01-06T10:00:00Z",
+		Content: "c",
+		Author:  feed.AtomPerson{Name: "a"},
+		Source:  &source,
+	})
+	if err != nil {
+		t.Fatalf("cannot encode entry: %s", err)
+	}
+
+	e := atom.NewEntry()
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+	if err := xmlutils.Walk(strings.NewReader(string(b)), e, &checker, 0); err != nil {
+		t.Fatalf("invalid atom:source: %s\n%s", err, b)
+	}
+
+	return e
+}
+
+func TestNewAtomSourceFromAtomFeed(t *testing.T) {
+	doc := `<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Example Blog</title>
+  <subtitle>All about examples</subtitle>
+  <id>urn:uuid:60a76c80-d399-11d9-b93C-0003939e0af6</id>
+  <updated>2024-01-06T12:00:00Z</updated>
+  <icon>http://example.org/favicon.ico</icon>
+  <link href="http://example.org/"/>
+  <link rel="self" href="http://example.org/feed.atom"/>
+  <author><name>John Doe</name></author>
+  <entry><title>copied</title><id>tag:elsewhere.org,2024:1</id><updated>2024-01-06T10:00:00Z</updated><content>c</content>
+    <source><id>tag:elsewhere.org,2024:feed</id><title>Elsewhere</title><updated>2024-01-05T10:00:00Z</updated><link rel="self" href="http://elsewhere.org/feed.atom"/></source>
+  </entry>
+  <entry><title>own</title><id>tag:example.org,2024:2</id><updated>2024-01-06T10:00:00Z</updated><content>c</content></entry>
+</feed>`
+
+	f := atom.NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+	checker.DisableErrorChecking("feed", atom.MissingSelfLink)
+	if err := xmlutils.Walk(strings.NewReader(doc), f, &checker, 0); err != nil {
+		t.Fatalf("cannot parse feed: %s", err)
+	}
+
+	origin := feed.SourceFromAtomFeed(f)
+
+	var entries []feed.BasicEntryBlock
+	for _, e := range f.Entries {
+		b := feed.BasicEntryBlock{}
+		b.PopulateFromAtomEntry(e)
+		entries = append(entries, b)
+	}
+
+	if entries[0].Source.IsZero() || entries[0].Source.Self != "http://elsewhere.org/feed.atom" || !entries[1].Source.IsZero() {
+		t.Fatalf("unexpected sources %+v, %+v", entries[0].Source, entries[1].Source)
+	}
+
+	// the copied entry keeps its source, the other one gets the feed's
+	e := checkAtomSource(t, feed.NewAtomSource(entries[0].Source, entries[0].Date))
+	if e.Source.Id.String() != "tag:elsewhere.org,2024:feed" || e.Source.Title.String() != "Elsewhere" {
+		t.Errorf("copied entry should keep its source, got %+v", feed.SourceFromAtomSource(e.Source))
+	}
+
+	e = checkAtomSource(t, feed.NewAtomSource(origin, entries[1].Date))
+	source := feed.SourceFromAtomSource(e.Source)
+	if source.Id != origin.Id || source.Title != "Example Blog" || source.Subtitle != "All about examples" || source.Icon != "http://example.org/favicon.ico" ||
+		source.Link != "http://example.org/" || source.Self != "http://example.org/feed.atom" || !source.Updated.Equal(origin.Updated) || len(source.Authors) != 1 {
+		t.Errorf("unexpected source %+v", source)
+	}
+}
+
+func TestNewAtomSourceFromRssChannel(t *testing.T) {
+	c := rss.NewChannel()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+	if err := xmlutils.Walk(strings.NewReader(`<channel><title>Liftoff News</title><link>http://liftoff.msfc.nasa.gov/</link><description>d</description></channel>`), c, &checker, 0); err != nil {
+		t.Fatalf("cannot parse channel: %s", err)
+	}
+
+	origin := feed.SourceFromRssChannel(c)
+	origin.Self = "http://liftoff.msfc.nasa.gov/rss.xml"
+
+	date := time.Date(2024, time.January, 6, 10, 0, 0, 0, time.UTC)
+	e := checkAtomSource(t, feed.NewAtomSource(origin, date))
+
+	source := feed.SourceFromAtomSource(e.Source)
+	if source.Id != "http://liftoff.msfc.nasa.gov/rss.xml" || source.Link != "http://liftoff.msfc.nasa.gov/" || !source.Updated.Equal(date) {
+		t.Errorf("unexpected source %+v", source)
+	}
+
+	e = checkAtomSource(t, feed.NewAtomSource(feed.BasicSource{Title: "No links"}, date))
+	if !strings.HasPrefix(e.Source.Id.String(), "urn:sha256:") {
+		t.Errorf("source without IRI should get a urn:sha256 id, got %s", e.Source.Id.String())
+	}
+
+	a := feed.NewAtomSource(feed.BasicSource{Link: "/a", Authors: []person.Person{{Name: "Jane"}}}, date)
+	b := feed.NewAtomSource(feed.BasicSource{Link: "/b", Authors: []person.Person{{Name: "Jane"}}}, date)
+	if a.Id == b.Id || !strings.HasPrefix(a.Id, "urn:sha256:") {
+		t.Errorf("untitled sources without IRI should get distinct urn:sha256 ids, got %s and %s", a.Id, b.Id)
+	}
+	if c := feed.NewAtomSource(feed.BasicSource{Link: "/a", Authors: []person.Person{{Name: "Jane"}}}, date.Add(time.Hour)); c.Id != a.Id {
+		t.Errorf("the id of a source should not depend on its date, got %s and %s", a.Id, c.Id)
+	}
+}
@@ -0,0 +1,323 @@
+// Package thread builds reply trees from comment feeds. Atom entries are linked
+// through the threading extension (thr:in-reply-to, RFC 4685), RSS items
+// through the wfw:commentRss and slash:comments extensions.
+//
+// Feeds must be parsed with the matching extensions registered in the
+// extension Manager (thr, wfw, slash), otherwise no reply is detected.
+package thread
+
+import (
+	"strconv"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/atom/extension/thr"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/slash"
+	"github.com/jloup/xml/feed/rss/extension/wfw"
+)
+
+// Sources of announced reply counts, as reported in CountMismatch
+const (
+	ThrTotal      = "thr:total"
+	ThrCount      = "thr:count"
+	SlashComments = "slash:comments"
+)
+
+// Ref identifies the entry a reply responds to, as given by thr:in-reply-to
+type Ref struct {
+	Ref    string
+	Href   string
+	Source string
+}
+
+// Node is an entry or an item of a thread. Exactly one of Entry and Item is set.
+type Node struct {
+	Id    string
+	Entry *atom.Entry
+	Item  *rss.Item
+
+	// InReplyTo lists the entries this one replies to
+	InReplyTo []Ref
+	// CommentsOf is the wfw:commentRss URL of the item this one replies to
+	CommentsOf string
+
+	Parent  *Node
+	Replies []*Node
+}
+
+// IsReply reports whether the node declares a parent
+func (n *Node) IsReply() bool {
+	return len(n.InReplyTo) > 0 || n.CommentsOf != ""
+}
+
+// CountMismatch reports a reply count announced by the feed which does not
+// match the number of direct replies found
+type CountMismatch struct {
+	Node      *Node
+	Source    string
+	Announced int
+	Actual    int
+}
+
+// Tree is the result of Builder.Build. Roots, Orphans and the nodes of Cycles
+// are disjoint; replies of any of them are reachable through Node.Replies.
+type Tree struct {
+	Roots []*Node
+	// Orphans are replies whose parent could not be found
+	Orphans []*Node
+	// Cycles are sets of nodes replying to each other. Their parent links are
+	// cut to keep the tree acyclic.
+	Cycles     [][]*Node
+	Mismatches []CountMismatch
+}
+
+// Builder collects entries from one or several feeds and links them together
+type Builder struct {
+	nodes []*Node
+	ids   map[string]*Node
+}
+
+func NewBuilder() *Builder {
+	return &Builder{ids: make(map[string]*Node)}
+}
+
+// AddAtomFeed adds all the entries of f
+func (b *Builder) AddAtomFeed(f *atom.Feed) {
+	for _, entry := range f.Entries {
+		b.AddAtomEntry(entry)
+	}
+}
+
+// AddAtomEntry adds e. An entry whose id has already been added is ignored
+// and the existing node is returned.
+func (b *Builder) AddAtomEntry(e *atom.Entry) *Node {
+	id := e.Id.String()
+	if n, ok := b.ids[id]; ok && id != "" {
+		return n
+	}
+
+	n := &Node{Id: id, Entry: e}
+	for _, irt := range thr.GetAllInReplyTo(e) {
+		n.InReplyTo = append(n.InReplyTo, Ref{Ref: irt.Ref.Value, Href: irt.Href.Value, Source: irt.Source.Value})
+	}
+
+	return b.add(n)
+}
+
+// AddRssChannel adds all the items of c
+func (b *Builder) AddRssChannel(c *rss.Channel) {
+	for _, item := range c.Items {
+		b.AddRssItem(item)
+	}
+}
+
+// AddRssItem adds i. Items are identified by their guid, or their link when
+// guid is missing.
+func (b *Builder) AddRssItem(i *rss.Item) *Node {
+	return b.addRssItem(i, "")
+}
+
+// AddRssComments adds the items of c, the comments feed announced with
+// wfw:commentRss at commentRss
+func (b *Builder) AddRssComments(commentRss string, c *rss.Channel) {
+	for _, item := range c.Items {
+		b.addRssItem(item, commentRss)
+	}
+}
+
+func (b *Builder) addRssItem(i *rss.Item, commentsOf string) *Node {
+	id := i.Guid.Content.String()
+	if id == "" {
+		id = i.Link.String()
+	}
+
+	if n, ok := b.ids[id]; ok && id != "" {
+		return n
+	}
+
+	return b.add(&Node{Id: id, Item: i, CommentsOf: commentsOf})
+}
+
+func (b *Builder) add(n *Node) *Node {
+	if n.Id != "" {
+		b.ids[n.Id] = n
+	}
+	b.nodes = append(b.nodes, n)
+
+	return n
+}
+
+// Build links the nodes added so far. It can be called again after adding
+// more feeds.
+func (b *Builder) Build() *Tree {
+	hrefs := make(map[string]*Node)
+	selves := make(map[string]*Node)
+	commentFeeds := make(map[string]*Node)
+
+	for _, n := range b.nodes {
+		n.Parent = nil
+		n.Replies = nil
+
+		switch {
+		case n.Entry != nil:
+			for _, link := range n.Entry.Links {
+				switch link.Rel.String() {
+				case "alternate":
+					setOnce(hrefs, link.Href.String(), n)
+				case "self":
+					setOnce(selves, link.Href.String(), n)
+				}
+			}
+
+		case n.Item != nil:
+			setOnce(hrefs, n.Item.Link.String(), n)
+			if commentRss, ok := wfw.GetCommentRss(n.Item); ok {
+				setOnce(commentFeeds, commentRss.String(), n)
+			}
+		}
+	}
+
+	for _, n := range b.nodes {
+		n.Parent = b.resolve(n, hrefs, selves, commentFeeds)
+	}
+
+	tree := &Tree{}
+	inCycle := make(map[*Node]bool)
+
+	for _, cycle := range findCycles(b.nodes) {
+		for _, n := range cycle {
+			n.Parent = nil
+			inCycle[n] = true
+		}
+		tree.Cycles = append(tree.Cycles, cycle)
+	}
+
+	for _, n := range b.nodes {
+		switch {
+		case n.Parent != nil:
+			n.Parent.Replies = append(n.Parent.Replies, n)
+		case inCycle[n]:
+		case n.IsReply():
+			tree.Orphans = append(tree.Orphans, n)
+		default:
+			tree.Roots = append(tree.Roots, n)
+		}
+	}
+
+	for _, n := range b.nodes {
+		tree.Mismatches = append(tree.Mismatches, reconcile(n)...)
+	}
+
+	return tree
+}
+
+func setOnce(index map[string]*Node, key string, n *Node) {
+	if _, ok := index[key]; !ok && key != "" {
+		index[key] = n
+	}
+}
+
+// resolve returns the parent of n: thr:in-reply-to ref is matched against
+// entry ids, then href against alternate links and source against self links
+func (b *Builder) resolve(n *Node, hrefs, selves, commentFeeds map[string]*Node) *Node {
+	for _, ref := range n.InReplyTo {
+		if p, ok := b.ids[ref.Ref]; ok && p != n {
+			return p
+		}
+	}
+
+	for _, ref := range n.InReplyTo {
+		if p, ok := hrefs[ref.Href]; ok && p != n {
+			return p
+		}
+		if p, ok := selves[ref.Source]; ok && p != n {
+			return p
+		}
+	}
+
+	if p, ok := commentFeeds[n.CommentsOf]; ok && p != n {
+		return p
+	}
+
+	return nil
+}
+
+func findCycles(nodes []*Node) [][]*Node {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var cycles [][]*Node
+	state := make(map[*Node]int)
+
+	for _, n := range nodes {
+		var path []*Node
+		cur := n
+
+		for cur != nil && state[cur] == unvisited {
+			state[cur] = visiting
+			path = append(path, cur)
+			cur = cur.Parent
+		}
+
+		if cur != nil && state[cur] == visiting {
+			for i, p := range path {
+				if p == cur {
+					cycles = append(cycles, path[i:])
+					break
+				}
+			}
+		}
+
+		for _, p := range path {
+			state[p] = visited
+		}
+	}
+
+	return cycles
+}
+
+func reconcile(n *Node) []CountMismatch {
+	var mismatches []CountMismatch
+	actual := len(n.Replies)
+
+	check := func(source string, announced int) {
+		if announced != actual {
+			mismatches = append(mismatches, CountMismatch{Node: n, Source: source, Announced: announced, Actual: actual})
+		}
+	}
+
+	switch {
+	case n.Entry != nil:
+		if total, ok := thr.GetTotal(n.Entry); ok {
+			if announced, err := strconv.Atoi(total.String()); err == nil {
+				check(ThrTotal, announced)
+			}
+		}
+
+		announced, found := 0, false
+		for _, link := range n.Entry.Links {
+			if link.Rel.String() != "replies" {
+				continue
+			}
+			if count, ok := thr.GetCount(link); ok {
+				if c, err := strconv.Atoi(count.String()); err == nil {
+					announced += c
+					found = true
+				}
+			}
+		}
+		if found {
+			check(ThrCount, announced)
+		}
+
+	case n.Item != nil:
+		if announced, ok := slash.GetCommentCount(n.Item); ok {
+			check(SlashComments, announced)
+		}
+	}
+
+	return mismatches
+}
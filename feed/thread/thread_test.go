@@ -0,0 +1,208 @@
+package thread
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/atom/extension/thr"
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/slash"
+	"github.com/jloup/xml/feed/rss/extension/wfw"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+const testPostsFeed = `
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:thr="http://purl.org/syndication/thread/1.0">
+  <entry>
+    <id>tag:example.org,2005:post</id>
+    <link href="http://example.org/post"/>
+    <link rel="replies" href="http://example.org/post/comments.atom" thr:count="3"/>
+    <thr:total>3</thr:total>
+  </entry>
+</feed>`
+
+const testCommentsFeed = `
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:thr="http://purl.org/syndication/thread/1.0">
+  <entry>
+    <id>tag:example.org,2005:c1</id>
+    <thr:in-reply-to ref="tag:example.org,2005:post"/>
+  </entry>
+  <entry>
+    <id>tag:example.org,2005:c2</id>
+    <link href="http://example.org/c2"/>
+    <thr:in-reply-to ref="tag:example.org,2005:c1"/>
+  </entry>
+  <entry>
+    <id>tag:example.org,2005:c3</id>
+    <thr:in-reply-to ref="tag:example.org,2005:unknown" href="http://example.org/post"/>
+  </entry>
+  <entry>
+    <id>tag:example.org,2005:orphan</id>
+    <thr:in-reply-to ref="tag:example.org,2005:missing"/>
+  </entry>
+  <entry>
+    <id>tag:example.org,2005:x</id>
+    <thr:in-reply-to ref="tag:example.org,2005:y"/>
+  </entry>
+  <entry>
+    <id>tag:example.org,2005:y</id>
+    <thr:in-reply-to ref="tag:example.org,2005:x"/>
+  </entry>
+  <entry>
+    <id>tag:example.org,2005:c1</id>
+    <thr:in-reply-to ref="tag:example.org,2005:post"/>
+  </entry>
+</feed>`
+
+func parseAtomFeed(t *testing.T, manager extension.Manager, s string) *atom.Feed {
+	f := atom.NewFeedExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(s), f, &checker, 0); err != nil {
+		t.Fatalf("cannot parse feed: %s", err)
+	}
+
+	return f
+}
+
+func parseRssChannel(t *testing.T, manager extension.Manager, s string) *rss.Channel {
+	c := rss.NewChannelExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+
+	if err := xmlutils.Walk(strings.NewReader(s), c, &checker, 0); err != nil {
+		t.Fatalf("cannot parse channel: %s", err)
+	}
+
+	return c
+}
+
+func ids(nodes []*Node) string {
+	var s []string
+	for _, n := range nodes {
+		s = append(s, n.Id[strings.LastIndex(n.Id, ":")+1:])
+	}
+	return strings.Join(s, ",")
+}
+
+func TestAtomThread(t *testing.T) {
+	manager := extension.Manager{}
+	thr.AddToManager(&manager)
+
+	b := NewBuilder()
+	b.AddAtomFeed(parseAtomFeed(t, manager, testPostsFeed))
+	b.AddAtomFeed(parseAtomFeed(t, manager, testCommentsFeed))
+
+	tree := b.Build()
+
+	if ids(tree.Roots) != "post" {
+		t.Fatalf("roots should be 'post', got '%s'", ids(tree.Roots))
+	}
+
+	post := tree.Roots[0]
+	if ids(post.Replies) != "c1,c3" {
+		t.Errorf("post replies should be 'c1,c3', got '%s'", ids(post.Replies))
+	}
+
+	if ids(post.Replies[0].Replies) != "c2" {
+		t.Errorf("c1 replies should be 'c2', got '%s'", ids(post.Replies[0].Replies))
+	}
+
+	if post.Replies[0].Replies[0].Parent != post.Replies[0] {
+		t.Errorf("c2 parent should be c1")
+	}
+
+	if ids(tree.Orphans) != "orphan" {
+		t.Errorf("orphans should be 'orphan', got '%s'", ids(tree.Orphans))
+	}
+
+	if len(tree.Cycles) != 1 || ids(tree.Cycles[0]) != "x,y" {
+		t.Errorf("cycles should be [x,y], got %v", tree.Cycles)
+	}
+
+	if len(tree.Mismatches) != 2 {
+		t.Fatalf("2 count mismatches expected, got %v", tree.Mismatches)
+	}
+
+	for _, m := range tree.Mismatches {
+		if m.Node != post || m.Announced != 3 || m.Actual != 2 {
+			t.Errorf("%s mismatch should be on post, 3 (announced) vs 2, got %v vs %v", m.Source, m.Announced, m.Actual)
+		}
+	}
+
+	if tree2 := b.Build(); ids(tree2.Roots[0].Replies) != "c1,c3" {
+		t.Errorf("Build should be idempotent, got '%s'", ids(tree2.Roots[0].Replies))
+	}
+}
+
+func TestRssThread(t *testing.T) {
+	manager := extension.Manager{}
+	wfw.AddToManager(&manager)
+	slash.AddToManager(&manager)
+
+	posts := parseRssChannel(t, manager, `
+<channel xmlns:wfw="http://wellformedweb.org/CommentAPI/" xmlns:slash="http://purl.org/rss/1.0/modules/slash/">
+  <item>
+    <guid>http://example.org/post</guid>
+    <wfw:commentRss>http://example.org/post/comments.rss</wfw:commentRss>
+    <slash:comments>2</slash:comments>
+  </item>
+  <item>
+    <guid>http://example.org/other</guid>
+    <slash:comments>0</slash:comments>
+  </item>
+</channel>`)
+
+	comments := parseRssChannel(t, manager, `
+<channel>
+  <item>
+    <link>http://example.org/post#comment-1</link>
+  </item>
+</channel>`)
+
+	if commentRss, ok := wfw.GetCommentRss(posts.Items[0]); !ok || commentRss.String() != "http://example.org/post/comments.rss" {
+		t.Fatalf("wfw:commentRss not parsed")
+	}
+
+	b := NewBuilder()
+	b.AddRssChannel(posts)
+	b.AddRssComments("http://example.org/post/comments.rss", comments)
+	b.AddRssComments("http://example.org/gone/comments.rss", comments)
+
+	tree := b.Build()
+
+	if len(tree.Roots) != 2 || len(tree.Roots[0].Replies) != 1 {
+		t.Fatalf("post should have 1 reply, got %v", tree.Roots)
+	}
+
+	if reply := tree.Roots[0].Replies[0]; reply.Id != "http://example.org/post#comment-1" || reply.Item == nil {
+		t.Errorf("reply should be identified by its link, got '%s'", reply.Id)
+	}
+
+	if len(tree.Orphans) != 0 {
+		t.Errorf("comments already added should not be added twice, got orphans %v", tree.Orphans)
+	}
+
+	if len(tree.Mismatches) != 1 || tree.Mismatches[0].Source != SlashComments || tree.Mismatches[0].Announced != 2 || tree.Mismatches[0].Actual != 1 {
+		t.Errorf("slash:comments mismatch 2 (announced) vs 1 expected, got %v", tree.Mismatches)
+	}
+}
+
+func TestSlashCommentsValidation(t *testing.T) {
+	manager := extension.Manager{}
+	slash.AddToManager(&manager)
+
+	c := rss.NewChannelExt(manager)
+	checker := xmlutils.NewErrorChecker(xmlutils.EnableAllError)
+
+	err := xmlutils.Walk(strings.NewReader(`
+<channel xmlns:slash="http://purl.org/rss/1.0/modules/slash/">
+  <title>t</title><link>http://example.org/</link><description>d</description>
+  <item><title>t</title><slash:comments>many</slash:comments></item>
+</channel>`), c, &checker, 0)
+
+	if err == nil || !err.Flag().Cmp(rss.NotPositiveNumber) {
+		t.Errorf("NotPositiveNumber expected, got %v", err)
+	}
+}
@@ -0,0 +1,417 @@
+package websub
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jloup/xml/feed"
+)
+
+// State is the state of a Subscription
+type State string
+
+const (
+	// Pending subscriptions wait for the hub to verify the intent
+	Pending State = "pending"
+	// Active subscriptions receive content until Expires
+	Active State = "active"
+	// Denied subscriptions have been refused by the hub, see Reason
+	Denied State = "denied"
+	// Unsubscribed subscriptions have been cancelled
+	Unsubscribed State = "unsubscribed"
+)
+
+// Subscription is a subscription to a topic. It is updated by the Subscriber
+// as the hub verifies intents: get a fresh copy with Subscriber.Get.
+type Subscription struct {
+	// Id identifies the subscription in its callback URL
+	Id       string
+	Hub      string
+	Topic    string
+	Callback string
+	// Secret signs the content pushed by the hub, no signature when empty
+	Secret string
+	// Lease is the lease duration requested, the hub decides when 0
+	Lease time.Duration
+	State State
+	// Expires is the end of the lease granted by the hub, zero when unknown
+	Expires time.Time
+	// Reason is given by the hub when it denies the subscription
+	Reason string
+
+	// mode is the intent waiting for verification, "" when none
+	mode string
+}
+
+// DefaultMaxBodySize bounds pushed content when neither
+// Subscriber.MaxBodySize nor ParseOptions.Limits.MaxInputSize is set
+const DefaultMaxBodySize = 10 << 20
+
+// Subscriber subscribes to topics and serves their callback URLs. It is safe
+// for concurrent use once its fields are set.
+type Subscriber struct {
+	// Callback is the public URL Subscriber is served at. The callback URL of
+	// each subscription is Callback followed by its Id.
+	Callback string
+	// Client sends requests to hubs, http.DefaultClient when nil
+	Client *http.Client
+	// ParseOptions are used to parse the pushed content with feed.ParseCustom.
+	// No error is checked when ErrorFlags is nil.
+	ParseOptions feed.ParseOptions
+	// MaxBodySize bounds the size of pushed content, larger requests are
+	// refused. Defaults to ParseOptions.Limits.MaxInputSize, then to
+	// DefaultMaxBodySize.
+	MaxBodySize int64
+	// NewFeed builds the UserFeed populated from pushed content. Defaults to
+	// returning a *feed.BasicFeed.
+	NewFeed func() feed.UserFeed
+	// OnContent is called for each content pushed by the hub of an active
+	// subscription, once parsed. Content with an invalid signature is
+	// ignored.
+	OnContent func(s Subscription, f feed.UserFeed)
+	// OnError, when set, is called when pushed content cannot be used: bad
+	// signature or parse error
+	OnError func(s Subscription, err error)
+	// Now defaults to time.Now
+	Now func() time.Time
+
+	mutex         sync.Mutex
+	subscriptions map[string]*Subscription
+}
+
+func (s *Subscriber) client() *http.Client {
+	if s.Client != nil {
+		return s.Client
+	}
+	return http.DefaultClient
+}
+
+func (s *Subscriber) maxBodySize() int64 {
+	if s.MaxBodySize > 0 {
+		return s.MaxBodySize
+	}
+	if s.ParseOptions.Limits.MaxInputSize > 0 {
+		return s.ParseOptions.Limits.MaxInputSize
+	}
+	return DefaultMaxBodySize
+}
+
+func (s *Subscriber) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// Get returns the subscription of id
+func (s *Subscriber) Get(id string) (Subscription, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if sub, ok := s.subscriptions[id]; ok {
+		return *sub, true
+	}
+	return Subscription{}, false
+}
+
+// Subscribe asks the first hub of d to send the updates of its topic. The
+// subscription is Pending until the hub verifies the intent on the callback
+// URL. secret may be empty; lease 0 lets the hub choose the lease duration.
+func (s *Subscriber) Subscribe(ctx context.Context, d Discovery, secret string, lease time.Duration) (Subscription, error) {
+	if !d.Ok() {
+		return Subscription{}, ErrNoHub
+	}
+
+	id, err := newId()
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	sub := &Subscription{
+		Id:       id,
+		Hub:      d.Hubs[0],
+		Topic:    d.Topic,
+		Callback: strings.TrimSuffix(s.Callback, "/") + "/" + id,
+		Secret:   secret,
+		Lease:    lease,
+		State:    Pending,
+		mode:     "subscribe",
+	}
+
+	s.mutex.Lock()
+	if s.subscriptions == nil {
+		s.subscriptions = make(map[string]*Subscription)
+	}
+	s.subscriptions[id] = sub
+	created := *sub
+	s.mutex.Unlock()
+
+	if err := s.request(ctx, created, "subscribe"); err != nil {
+		s.mutex.Lock()
+		delete(s.subscriptions, id)
+		s.mutex.Unlock()
+
+		return created, err
+	}
+
+	return created, nil
+}
+
+// Unsubscribe asks the hub to stop sending the updates of the subscription of
+// id. The subscription stays Active until the hub verifies the intent; when
+// the request fails, the hub may still renew it.
+func (s *Subscriber) Unsubscribe(ctx context.Context, id string) error {
+	s.mutex.Lock()
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		s.mutex.Unlock()
+		return fmt.Errorf("websub: unknown subscription %s", id)
+	}
+
+	previous := sub.mode
+	sub.mode = "unsubscribe"
+	current := *sub
+	s.mutex.Unlock()
+
+	if err := s.request(ctx, current, "unsubscribe"); err != nil {
+		s.mutex.Lock()
+		if sub.mode == "unsubscribe" {
+			sub.mode = previous
+		}
+		s.mutex.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// request sends a subscription request, accepted by the hub with a 2xx status
+// (202 Accepted as per the specification)
+func (s *Subscriber) request(ctx context.Context, sub Subscription, mode string) error {
+	form := url.Values{}
+	form.Set("hub.mode", mode)
+	form.Set("hub.topic", sub.Topic)
+	form.Set("hub.callback", sub.Callback)
+
+	if mode == "subscribe" {
+		if sub.Secret != "" {
+			form.Set("hub.secret", sub.Secret)
+		}
+		if sub.Lease > 0 {
+			form.Set("hub.lease_seconds", strconv.Itoa(int(sub.Lease/time.Second)))
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Hub, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return fmt.Errorf("websub: %s request to %s: %w", mode, sub.Hub, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("websub: %s request to %s: %s %s", mode, sub.Hub, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+func newId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ServeHTTP serves the callback URLs: GET requests verify intents and POST
+// requests push content
+func (s *Subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := path.Base(r.URL.Path)
+
+	switch r.Method {
+	case http.MethodGet:
+		s.verify(w, r, id)
+	case http.MethodPost:
+		s.receive(w, r, id)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verify answers the verification of an intent by echoing hub.challenge, when
+// the intent is the one of the subscription. Hubs may verify an active
+// subscription again, e.g. to renew its lease.
+func (s *Subscriber) verify(w http.ResponseWriter, r *http.Request, id string) {
+	query := r.URL.Query()
+	mode := query.Get("hub.mode")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sub, ok := s.subscriptions[id]
+	if !ok || query.Get("hub.topic") != sub.Topic {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case mode == "denied":
+		sub.State = Denied
+		sub.Reason = query.Get("hub.reason")
+		sub.mode = ""
+		w.WriteHeader(http.StatusOK)
+		return
+
+	case mode == "subscribe" && (sub.mode == "subscribe" || sub.mode == "" && sub.State == Active):
+		sub.State = Active
+		sub.Expires = time.Time{}
+		if seconds, err := strconv.Atoi(query.Get("hub.lease_seconds")); err == nil && seconds > 0 {
+			sub.Expires = s.now().Add(time.Duration(seconds) * time.Second)
+		}
+
+	case mode == "unsubscribe" && sub.mode == "unsubscribe":
+		sub.State = Unsubscribed
+
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	sub.mode = ""
+
+	w.Header().Set("Content-Type", "text/plain")
+	io.WriteString(w, query.Get("hub.challenge"))
+}
+
+// receive parses content pushed for an active subscription. As required by
+// the specification, content whose signature does not match is ignored but
+// still acknowledged.
+func (s *Subscriber) receive(w http.ResponseWriter, r *http.Request, id string) {
+	sub, ok := s.Get(id)
+	if !ok || sub.State != Active {
+		http.Error(w, "no such subscription", http.StatusGone)
+		return
+	}
+
+	max := s.maxBodySize()
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, max+1))
+	if int64(len(body)) > max {
+		http.Error(w, "content too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+
+	if sub.Secret != "" {
+		if err := CheckSignature(sub.Secret, r.Header.Get("X-Hub-Signature"), body); err != nil {
+			s.error(sub, err)
+			return
+		}
+	}
+
+	// the extension Manager and the Limits of the caller are kept
+	options := s.ParseOptions
+	if options.ErrorFlags == nil {
+		options.ErrorFlags = feed.DefaultOptions.ErrorFlags
+	}
+	options.ContentType = r.Header.Get("Content-Type")
+
+	var f feed.UserFeed = &feed.BasicFeed{}
+	if s.NewFeed != nil {
+		f = s.NewFeed()
+	}
+
+	if err := feed.ParseCustom(bytes.NewReader(body), f, options); err != nil {
+		s.error(sub, fmt.Errorf("websub: cannot parse content of %s: %w", sub.Topic, err))
+		return
+	}
+
+	if s.OnContent != nil {
+		s.OnContent(sub, f)
+	}
+}
+
+func (s *Subscriber) error(sub Subscription, err error) {
+	if s.OnError != nil {
+		s.OnError(sub, err)
+	}
+}
+
+var signatureHashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// CheckSignature checks the X-Hub-Signature header value signature, e.g.
+// "sha256=<hex HMAC>", of body signed with secret
+func CheckSignature(secret, signature string, body []byte) error {
+	method := strings.SplitN(signature, "=", 2)
+	if len(method) != 2 {
+		return fmt.Errorf("websub: missing or malformed X-Hub-Signature '%s'", signature)
+	}
+
+	newHash, ok := signatureHashes[strings.ToLower(method[0])]
+	if !ok {
+		return fmt.Errorf("websub: unsupported X-Hub-Signature method '%s'", method[0])
+	}
+
+	expected, err := hex.DecodeString(method[1])
+	if err != nil {
+		return fmt.Errorf("websub: malformed X-Hub-Signature '%s'", signature)
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return fmt.Errorf("websub: X-Hub-Signature does not match the content")
+	}
+
+	return nil
+}
+
+// Sign returns the X-Hub-Signature header value of body signed with secret
+// using method ("sha1", "sha256", "sha384" or "sha512"), e.g. for a hub
+func Sign(method, secret string, body []byte) (string, error) {
+	newHash, ok := signatureHashes[method]
+	if !ok {
+		return "", fmt.Errorf("websub: unsupported signature method '%s'", method)
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+
+	return method + "=" + hex.EncodeToString(mac.Sum(nil)), nil
+}
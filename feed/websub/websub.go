@@ -0,0 +1,60 @@
+// Package websub subscribes to feeds advertising a WebSub (formerly
+// PubSubHubbub, https://www.w3.org/TR/websub/) hub, to receive their updates
+// as soon as they are published instead of polling them.
+//
+// The hub and the topic are the "hub" and "self" links of a feed: atom:link of
+// Atom feeds, and of RSS channels parsed with the atomlink extension
+// registered. A Subscriber sends subscription requests to hubs and is the
+// http.Handler of the callback URL: it answers the verification of intents and
+// receives the content pushed by hubs, checking its X-Hub-Signature.
+package websub
+
+import (
+	"errors"
+
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/atomlink"
+)
+
+// ErrNoHub is returned when subscribing to a feed which does not advertise
+// both a hub and its self link
+var ErrNoHub = errors.New("websub: the feed does not advertise a hub and a self link")
+
+// Discovery gathers the links needed to subscribe to a feed
+type Discovery struct {
+	// Hubs are the hrefs of the hub links, in document order
+	Hubs []string
+	// Topic is the href of the self link
+	Topic string
+}
+
+// Ok reports whether the feed can be subscribed to
+func (d Discovery) Ok() bool {
+	return len(d.Hubs) > 0 && d.Topic != ""
+}
+
+func fromLinks(links atom.Links) Discovery {
+	d := Discovery{}
+
+	for _, hub := range links.ByRel("hub") {
+		d.Hubs = append(d.Hubs, hub.Href.String())
+	}
+
+	if self := links.First("self"); self != nil {
+		d.Topic = self.Href.String()
+	}
+
+	return d
+}
+
+// FromAtomFeed finds the hubs and the topic of f
+func FromAtomFeed(f *atom.Feed) Discovery {
+	return fromLinks(f.Links)
+}
+
+// FromRssChannel finds the hubs and the topic of c, which must have been
+// parsed with the atomlink extension registered
+func FromRssChannel(c *rss.Channel) Discovery {
+	return fromLinks(atomlink.GetLinks(&c.Extension.Store))
+}
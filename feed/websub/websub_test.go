@@ -0,0 +1,305 @@
+package websub
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jloup/xml/feed"
+	"github.com/jloup/xml/feed/atom"
+	"github.com/jloup/xml/feed/extension"
+	"github.com/jloup/xml/feed/rss"
+	"github.com/jloup/xml/feed/rss/extension/atomlink"
+	xmlutils "github.com/jloup/xml/utils"
+)
+
+func TestDiscovery(t *testing.T) {
+	f := atom.NewFeed()
+	checker := xmlutils.NewErrorChecker(xmlutils.DisableAllError)
+	if err := xmlutils.Walk(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom">
+  <link rel="hub" href="http://hub1.example.org/"/><link rel="self" href="http://example.org/feed.atom"/><link rel="hub" href="http://hub2.example.org/"/>
+</feed>`), f, &checker, 0); err != nil {
+		t.Fatalf("cannot parse feed: %s", err)
+	}
+
+	if d := FromAtomFeed(f); !d.Ok() || d.Topic != "http://example.org/feed.atom" || len(d.Hubs) != 2 || d.Hubs[1] != "http://hub2.example.org/" {
+		t.Errorf("unexpected atom discovery %+v", d)
+	}
+
+	manager := extension.Manager{}
+	atomlink.AddToManager(&manager)
+
+	c := rss.NewChannelExt(manager)
+	if err := xmlutils.Walk(strings.NewReader(`<channel xmlns:atom="http://www.w3.org/2005/Atom">
+  <atom:link rel="hub" href="http://hub1.example.org/"/><atom:link rel="self" href="http://example.org/rss.xml"/>
+</channel>`), c, &checker, 0); err != nil {
+		t.Fatalf("cannot parse channel: %s", err)
+	}
+
+	if d := FromRssChannel(c); !d.Ok() || d.Topic != "http://example.org/rss.xml" || len(d.Hubs) != 1 {
+		t.Errorf("unexpected rss discovery %+v", d)
+	}
+
+	if _, err := (&Subscriber{}).Subscribe(context.Background(), Discovery{Topic: "http://example.org/rss.xml"}, "", 0); err != ErrNoHub {
+		t.Errorf("expected ErrNoHub, got %v", err)
+	}
+}
+
+func TestCheckSignature(t *testing.T) {
+	body := []byte("<feed/>")
+	valid, _ := Sign("sha256", "secret", body)
+
+	var testdata = []struct {
+		Signature string
+		Valid     bool
+	}{
+		{valid, true},
+		{strings.Replace(valid, "sha256", "SHA256", 1), true},
+		{"sha1=" + strings.Repeat("0", 40), false},
+		{"md5=00", false},
+		{"sha256=zz", false},
+		{"", false},
+	}
+
+	for _, test := range testdata {
+		if err := CheckSignature("secret", test.Signature, body); (err == nil) != test.Valid {
+			t.Errorf("%s: expected valid %v, got %v", test.Signature, test.Valid, err)
+		}
+	}
+}
+
+// fakeHub accepts subscription requests and verifies them on the callback
+// URL, sending the challenge check result on verified
+type fakeHub struct {
+	lease    string
+	requests chan url.Values
+	verified chan error
+}
+
+func (h *fakeHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	h.requests <- r.PostForm
+	w.WriteHeader(http.StatusAccepted)
+
+	form := r.PostForm
+	go func() {
+		h.verified <- verifyIntent(form.Get("hub.callback"), url.Values{
+			"hub.mode":          {form.Get("hub.mode")},
+			"hub.topic":         {form.Get("hub.topic")},
+			"hub.challenge":     {"challenge-" + form.Get("hub.mode")},
+			"hub.lease_seconds": {h.lease},
+		})
+	}()
+}
+
+func verifyIntent(callback string, query url.Values) error {
+	resp, err := http.Get(callback + "?" + query.Encode())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != query.Get("hub.challenge") {
+		return fmt.Errorf("verification failed: %s '%s'", resp.Status, body)
+	}
+	return nil
+}
+
+func push(t *testing.T, callback, signature string, body string) int {
+	req, _ := http.NewRequest(http.MethodPost, callback, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/atom+xml")
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature", signature)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("cannot push content: %s", err)
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+func TestSubscriber(t *testing.T) {
+	now := time.Date(2024, time.January, 6, 10, 0, 0, 0, time.UTC)
+	contents := make(chan string, 1)
+	errors := make(chan error, 1)
+
+	subscriber := &Subscriber{
+		Now: func() time.Time { return now },
+		OnContent: func(s Subscription, f feed.UserFeed) {
+			contents <- f.(*feed.BasicFeed).Entries[0].Title
+		},
+		OnError: func(s Subscription, err error) { errors <- err },
+	}
+
+	callbacks := httptest.NewServer(subscriber)
+	defer callbacks.Close()
+	subscriber.Callback = callbacks.URL + "/websub/"
+
+	hub := &fakeHub{lease: "3600", requests: make(chan url.Values, 1), verified: make(chan error, 1)}
+	hubServer := httptest.NewServer(hub)
+	defer hubServer.Close()
+
+	sub, err := subscriber.Subscribe(context.Background(), Discovery{Hubs: []string{hubServer.URL}, Topic: "http://example.org/feed.atom"}, "secret", time.Hour)
+	if err != nil {
+		t.Fatalf("cannot subscribe: %s", err)
+	}
+
+	if form := <-hub.requests; form.Get("hub.mode") != "subscribe" || form.Get("hub.secret") != "secret" || form.Get("hub.lease_seconds") != "3600" || form.Get("hub.callback") != sub.Callback {
+		t.Errorf("unexpected subscription request %v", form)
+	}
+
+	if err := <-hub.verified; err != nil {
+		t.Fatalf("subscription: %s", err)
+	}
+
+	if sub, _ = subscriber.Get(sub.Id); sub.State != Active || !sub.Expires.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected subscription %+v", sub)
+	}
+
+	// intents which have not been requested are refused
+	if err := verifyIntent(sub.Callback, url.Values{"hub.mode": {"subscribe"}, "hub.topic": {"http://example.org/other.atom"}, "hub.challenge": {"c"}}); err == nil {
+		t.Errorf("verification of another topic should fail")
+	}
+	if err := verifyIntent(sub.Callback, url.Values{"hub.mode": {"unsubscribe"}, "hub.topic": {sub.Topic}, "hub.challenge": {"c"}}); err == nil {
+		t.Errorf("verification of an unsubscription which has not been requested should fail")
+	}
+
+	content := `<feed xmlns="http://www.w3.org/2005/Atom"><title>t</title><entry><title>pushed</title></entry></feed>`
+	signature, _ := Sign("sha1", "secret", []byte(content))
+
+	if status := push(t, sub.Callback, signature, content); status != http.StatusAccepted || <-contents != "pushed" {
+		t.Errorf("content should have been delivered (%d)", status)
+	}
+
+	if status := push(t, sub.Callback, signature, content+" "); status != http.StatusAccepted || <-errors == nil || len(contents) != 0 {
+		t.Errorf("content with a bad signature should be acknowledged and ignored (%d)", status)
+	}
+
+	if err := subscriber.Unsubscribe(context.Background(), sub.Id); err != nil {
+		t.Fatalf("cannot unsubscribe: %s", err)
+	}
+
+	if form := <-hub.requests; form.Get("hub.mode") != "unsubscribe" || form.Get("hub.secret") != "" {
+		t.Errorf("unexpected unsubscription request %v", form)
+	}
+
+	if err := <-hub.verified; err != nil {
+		t.Fatalf("unsubscription: %s", err)
+	}
+
+	if sub, _ = subscriber.Get(sub.Id); sub.State != Unsubscribed {
+		t.Errorf("unexpected subscription %+v", sub)
+	}
+
+	if status := push(t, sub.Callback, signature, content); status != http.StatusGone {
+		t.Errorf("content of a cancelled subscription should be refused, got %d", status)
+	}
+}
+
+func TestSubscriberDenied(t *testing.T) {
+	subscriber := &Subscriber{}
+	callbacks := httptest.NewServer(subscriber)
+	defer callbacks.Close()
+	subscriber.Callback = callbacks.URL
+
+	hubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("hub.topic") == "http://example.org/refused.atom" {
+			http.Error(w, "unknown topic", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer hubServer.Close()
+
+	if _, err := subscriber.Subscribe(context.Background(), Discovery{Hubs: []string{hubServer.URL}, Topic: "http://example.org/refused.atom"}, "", 0); err == nil || !strings.Contains(err.Error(), "unknown topic") {
+		t.Errorf("expected the hub error, got %v", err)
+	}
+
+	sub, err := subscriber.Subscribe(context.Background(), Discovery{Hubs: []string{hubServer.URL}, Topic: "http://example.org/feed.atom"}, "", 0)
+	if err != nil {
+		t.Fatalf("cannot subscribe: %s", err)
+	}
+
+	resp, err := http.Get(sub.Callback + "?" + url.Values{"hub.mode": {"denied"}, "hub.topic": {sub.Topic}, "hub.reason": {"spam"}}.Encode())
+	if err != nil {
+		t.Fatalf("cannot deny: %s", err)
+	}
+	resp.Body.Close()
+
+	if sub, _ = subscriber.Get(sub.Id); resp.StatusCode != http.StatusOK || sub.State != Denied || sub.Reason != "spam" {
+		t.Errorf("unexpected subscription %+v (%d)", sub, resp.StatusCode)
+	}
+}
+
+func TestSubscriberOptions(t *testing.T) {
+	manager := extension.Manager{}
+	atomlink.AddToManager(&manager)
+
+	topics := make(chan string, 1)
+	subscriber := &Subscriber{
+		ParseOptions: feed.ParseOptions{ExtensionManager: manager},
+		MaxBodySize:  512,
+		NewFeed:      func() feed.UserFeed { return &topicFeed{} },
+		OnContent:    func(s Subscription, f feed.UserFeed) { topics <- f.(*topicFeed).topic },
+	}
+	callbacks := httptest.NewServer(subscriber)
+	defer callbacks.Close()
+	subscriber.Callback = callbacks.URL
+
+	hubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("hub.mode") == "unsubscribe" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer hubServer.Close()
+
+	sub, err := subscriber.Subscribe(context.Background(), Discovery{Hubs: []string{hubServer.URL}, Topic: "http://example.org/rss.xml"}, "", 0)
+	if err != nil {
+		t.Fatalf("cannot subscribe: %s", err)
+	}
+
+	renew := url.Values{"hub.mode": {"subscribe"}, "hub.topic": {sub.Topic}, "hub.challenge": {"c"}}
+	if err := verifyIntent(sub.Callback, renew); err != nil {
+		t.Fatalf("subscription: %s", err)
+	}
+
+	// a failed unsubscription does not prevent the hub from renewing the lease
+	if err := subscriber.Unsubscribe(context.Background(), sub.Id); err == nil {
+		t.Fatalf("unsubscription should fail")
+	}
+	if err := verifyIntent(sub.Callback, renew); err != nil {
+		t.Errorf("renewal after a failed unsubscription: %s", err)
+	}
+
+	// the extension Manager of the caller is used without ErrorFlags
+	content := `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom"><channel><atom:link rel="self" href="http://example.org/rss.xml"/></channel></rss>`
+	if status := push(t, sub.Callback, "", content); status != http.StatusAccepted || <-topics != "http://example.org/rss.xml" {
+		t.Errorf("content should have been parsed with the atomlink extension (%d)", status)
+	}
+
+	if status := push(t, sub.Callback, "", content+strings.Repeat(" ", 512)); status != http.StatusRequestEntityTooLarge || len(topics) != 0 {
+		t.Errorf("content larger than MaxBodySize should be refused, got %d", status)
+	}
+}
+
+// topicFeed records the topic of a pushed RSS channel
+type topicFeed struct {
+	feed.BasicFeed
+	topic string
+}
+
+func (f *topicFeed) PopulateFromRssChannel(c *rss.Channel) {
+	f.topic = FromRssChannel(c).Topic
+}
@@ -110,7 +110,6 @@ func (u *ErrorChecker) CheckFlag(element string, mask ParserError) bool {
 	} else {
 		return utils.Intersect(u.elementErrors[index].flag, mask.Flag())
 	}
-	return false
 }
 
 func (u *ErrorChecker) ErrorWithCode(element string, mask ParserError) utils.ErrorFlagged {
@@ -120,5 +119,4 @@ func (u *ErrorChecker) ErrorWithCode(element string, mask ParserError) utils.Err
 	} else {
 		return mask.ErrorWithCode(u.elementErrors[index].flag)
 	}
-	return nil
 }
@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+)
+
+type EncodingSource int
+
+const (
+	// DefaultSource is used when nothing announces the encoding: XML defaults
+	// to UTF-8
+	DefaultSource EncodingSource = iota
+	// BOMSource is a byte order mark
+	BOMSource
+	// ContentTypeSource is the charset parameter of the Content-Type passed
+	// by the caller, e.g. from HTTP headers
+	ContentTypeSource
+	// DeclarationSource is the encoding of the XML declaration
+	DeclarationSource
+	// SniffingSource is the content itself: the announced encoding, if any,
+	// does not match the bytes of the document
+	SniffingSource
+)
+
+var encodingSourceNames = []string{"Default", "BOM", "ContentType", "Declaration", "Sniffing"}
+
+func (s EncodingSource) String() string {
+	if s < 0 || int(s) >= len(encodingSourceNames) {
+		return "EncodingSource(" + strconv.Itoa(int(s)) + ")"
+	}
+	return encodingSourceNames[s]
+}
+
+// EncodingReport tells how the encoding of a document has been detected.
+// Encodings are named by their canonical name in the WHATWG encoding
+// standard (see charset.Lookup), e.g. "utf-8" or "windows-1252".
+type EncodingReport struct {
+	// Encoding is the encoding the document is decoded from
+	Encoding string
+	// Source is where Encoding comes from
+	Source EncodingSource
+
+	// BOM is the encoding of the byte order mark, ContentType the charset of
+	// the Content-Type and Declared the encoding of the XML declaration, as
+	// found in the document. They are empty when absent.
+	BOM         string
+	ContentType string
+	Declared    string
+
+	// Fallback explains why the document is not decoded from the encoding
+	// announced by its highest priority source. It is empty when it is.
+	Fallback string
+}
+
+var (
+	utf8BOM    = []byte{0xef, 0xbb, 0xbf}
+	utf16BEBOM = []byte{0xfe, 0xff}
+	utf16LEBOM = []byte{0xff, 0xfe}
+
+	utf16BEDeclaration = []byte{0x00, '<', 0x00, '?'}
+	utf16LEDeclaration = []byte{'<', 0x00, '?', 0x00}
+
+	declarationEncoding = regexp.MustCompile(`^\s*<\?xml\s[^>]*?\bencoding\s*=\s*["']([A-Za-z0-9._:-]*)["']`)
+)
+
+const declarationMaxLength = 1024
+
+func readBOM(b []byte) (name string, length int) {
+	switch {
+	case bytes.HasPrefix(b, utf8BOM):
+		return "utf-8", len(utf8BOM)
+	case bytes.HasPrefix(b, utf16BEBOM):
+		return "utf-16be", len(utf16BEBOM)
+	case bytes.HasPrefix(b, utf16LEBOM):
+		return "utf-16le", len(utf16LEBOM)
+	}
+	return "", 0
+}
+
+func sniffUTF16(b []byte) string {
+	switch {
+	case bytes.HasPrefix(b, utf16BEDeclaration):
+		return "utf-16be"
+	case bytes.HasPrefix(b, utf16LEDeclaration):
+		return "utf-16le"
+	}
+	return ""
+}
+
+func isUTF16(name string) bool {
+	return name == "utf-16be" || name == "utf-16le"
+}
+
+// readDeclaration returns the encoding label of the XML declaration heading b,
+// and its position in b
+func readDeclaration(b []byte) (label string, loc []int) {
+	if len(b) > declarationMaxLength {
+		b = b[:declarationMaxLength]
+	}
+
+	m := declarationEncoding.FindSubmatchIndex(b)
+	if m == nil {
+		return "", nil
+	}
+
+	return string(b[m[2]:m[3]]), m[2:4]
+}
+
+func contentTypeCharset(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return params["charset"]
+}
+
+func hasNonASCII(b []byte) bool {
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectEncoding detects the encoding of the XML document b. contentType is
+// the Content-Type of the document, e.g. from HTTP headers; it may be empty.
+//
+// The encoding announced by the byte order mark, then by the charset of
+// contentType, then by the XML declaration is used. The bytes of the document
+// are checked against it: a document announced as UTF-8 which is not valid
+// UTF-8 is decoded as windows-1252, and a document announced with a single
+// byte encoding which is valid UTF-8 (and not plain ASCII) is decoded as
+// UTF-8.
+func DetectEncoding(b []byte, contentType string) EncodingReport {
+	r := EncodingReport{}
+
+	bom, bomLength := readBOM(b)
+	r.BOM = bom
+	body := b[bomLength:]
+
+	utf16 := sniffUTF16(body)
+	if isUTF16(bom) {
+		utf16 = bom
+	}
+
+	r.ContentType = contentTypeCharset(contentType)
+
+	head := body
+	if utf16 != "" {
+		if len(head) > 2*declarationMaxLength {
+			head = head[:2*declarationMaxLength]
+		}
+		head, _ = decodeBytes(utf16, head)
+	}
+	r.Declared, _ = readDeclaration(head)
+
+	var fallbacks []string
+	for _, candidate := range []struct {
+		label  string
+		source EncodingSource
+	}{
+		{r.BOM, BOMSource},
+		{r.ContentType, ContentTypeSource},
+		{r.Declared, DeclarationSource},
+		{utf16, SniffingSource},
+	} {
+		if candidate.label == "" {
+			continue
+		}
+
+		if _, name := charset.Lookup(candidate.label); name != "" {
+			r.Encoding, r.Source = name, candidate.source
+			break
+		}
+		fallbacks = append(fallbacks, fmt.Sprintf("unknown encoding '%s' announced by %s", candidate.label, candidate.source))
+	}
+
+	if r.Encoding == "" {
+		r.Encoding = "utf-8"
+		if len(fallbacks) > 0 {
+			r.Source = SniffingSource
+		}
+	}
+
+	if r.Source == BOMSource || isUTF16(r.Encoding) {
+		r.Fallback = strings.Join(fallbacks, "; ")
+		return r
+	}
+
+	switch {
+	case r.Encoding == "utf-8" && !utf8.Valid(body):
+		fallbacks = append(fallbacks, fmt.Sprintf("document is not valid utf-8, announced by %s", r.Source))
+		r.Encoding, r.Source = "windows-1252", SniffingSource
+
+	case r.Encoding != "utf-8" && hasNonASCII(body) && utf8.Valid(body):
+		fallbacks = append(fallbacks, fmt.Sprintf("document is valid utf-8, although %s announces %s", r.Source, r.Encoding))
+		r.Encoding, r.Source = "utf-8", SniffingSource
+	}
+
+	r.Fallback = strings.Join(fallbacks, "; ")
+	return r
+}
+
+func decodeBytes(name string, b []byte) ([]byte, error) {
+	e, _ := charset.Lookup(name)
+	if e == nil {
+		return nil, fmt.Errorf("unknown encoding '%s'", name)
+	}
+	return e.NewDecoder().Bytes(b)
+}
+
+// ToUTF8 decodes the XML document b from the encoding detected by
+// DetectEncoding. The byte order mark is removed, and the encoding of the XML
+// declaration is set to UTF-8, so that the result can be decoded without any
+// transcoding. When b can't be decoded, it is returned as is and Fallback
+// tells why.
+func ToUTF8(b []byte, contentType string) ([]byte, EncodingReport) {
+	r := DetectEncoding(b, contentType)
+
+	_, bomLength := readBOM(b)
+	body := b[bomLength:]
+
+	if r.Encoding != "utf-8" {
+		decoded, err := decodeBytes(r.Encoding, body)
+		if err != nil {
+			r.Fallback = fmt.Sprintf("cannot decode %s: %s", r.Encoding, err)
+			return b, r
+		}
+		body = decoded
+	}
+
+	if label, loc := readDeclaration(body); loc != nil && !strings.EqualFold(label, "utf-8") {
+		fixed := make([]byte, 0, len(body)+len("utf-8"))
+		fixed = append(fixed, body[:loc[0]]...)
+		fixed = append(fixed, "utf-8"...)
+		body = append(fixed, body[loc[1]:]...)
+	}
+
+	return body, r
+}
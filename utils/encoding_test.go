@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestToUTF8(t *testing.T) {
+	tests := []struct {
+		in          string
+		contentType string
+		out         string
+		encoding    string
+		source      EncodingSource
+		fallback    bool
+	}{
+		{"<a>é</a>", "", "<a>é</a>", "utf-8", DefaultSource, false},
+		{"<?xml version=\"1.0\" encoding=\"UTF-8\"?><a>é</a>", "", "<?xml version=\"1.0\" encoding=\"UTF-8\"?><a>é</a>", "utf-8", DeclarationSource, false},
+		{"<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><a>\xe9</a>", "", "<?xml version=\"1.0\" encoding=\"utf-8\"?><a>é</a>", "windows-1252", DeclarationSource, false},
+		// mislabeled documents
+		{"<?xml version=\"1.0\" encoding=\"utf-8\"?><a>\x93q\x94</a>", "", "<?xml version=\"1.0\" encoding=\"utf-8\"?><a>“q”</a>", "windows-1252", SniffingSource, true},
+		{"<?xml version=\"1.0\" encoding=\"iso-8859-1\"?><a>é</a>", "", "<?xml version=\"1.0\" encoding=\"utf-8\"?><a>é</a>", "utf-8", SniffingSource, true},
+		{"<?xml version='1.0' encoding='klingon'?><a>\xe9</a>", "", "<?xml version='1.0' encoding='utf-8'?><a>é</a>", "windows-1252", SniffingSource, true},
+		// Content-Type overrides the declaration
+		{"<?xml version=\"1.0\" encoding=\"utf-8\"?><a>\xe9</a>", "application/rss+xml; charset=iso-8859-15", "<?xml version=\"1.0\" encoding=\"utf-8\"?><a>é</a>", "iso-8859-15", ContentTypeSource, false},
+		{"<a>é</a>", "text/xml; charset=utf-8", "<a>é</a>", "utf-8", ContentTypeSource, false},
+		// BOM overrides everything
+		{"\xef\xbb\xbf<?xml version=\"1.0\" encoding=\"windows-1252\"?><a>é</a>", "text/xml; charset=iso-8859-1", "<?xml version=\"1.0\" encoding=\"utf-8\"?><a>é</a>", "utf-8", BOMSource, false},
+		{"\xff\xfe<\x00a\x00>\x00\xe9\x00<\x00/\x00a\x00>\x00", "", "<a>é</a>", "utf-16le", BOMSource, false},
+		{"\x00<\x00?\x00x\x00m\x00l\x00 \x00?\x00>\x00<\x00a\x00/\x00>", "", "<?xml ?><a/>", "utf-16be", SniffingSource, false},
+	}
+
+	for i, test := range tests {
+		out, report := ToUTF8([]byte(test.in), test.contentType)
+
+		if string(out) != test.out {
+			t.Errorf("test #%d: expected '%s', got '%s'", i, test.out, out)
+		}
+
+		if report.Encoding != test.encoding || report.Source != test.source {
+			t.Errorf("test #%d: expected %s from %s, got %s from %s", i, test.encoding, test.source, report.Encoding, report.Source)
+		}
+
+		if (report.Fallback != "") != test.fallback {
+			t.Errorf("test #%d: unexpected fallback '%s'", i, report.Fallback)
+		}
+	}
+}
+
+func TestWalkMislabeled(t *testing.T) {
+	checker := NewErrorChecker(EnableAllError)
+
+	for _, doc := range []string{
+		"<?xml version=\"1.0\" encoding=\"utf-8\"?><a>caf\xe9</a>",
+		"\xef\xbb\xbf<?xml version=\"1.0\" encoding=\"iso-8859-1\"?><a>café</a>",
+	} {
+		if err := Walk(strings.NewReader(doc), nopVisitor{}, &checker, 0); err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+	}
+}
+
+type textVisitor struct{ text *string }
+
+func (v textVisitor) ProcessStartElement(el StartElement) (Visitor, ParserError) { return v, nil }
+func (v textVisitor) ProcessEndElement(el xml.EndElement) (Visitor, ParserError) { return v, nil }
+func (v textVisitor) ProcessCharData(el xml.CharData) (Visitor, ParserError) {
+	*v.text += string(el)
+	return v, nil
+}
+
+func TestWalkContentType(t *testing.T) {
+	checker := NewErrorChecker(EnableAllError)
+
+	tests := []struct {
+		contentType string
+		text        string
+	}{
+		{"", "¤"},
+		{"application/rss+xml; charset=iso-8859-15", "€"},
+	}
+
+	for i, test := range tests {
+		var text string
+		err := WalkContext(context.Background(), strings.NewReader("<a>\xa4</a>"), textVisitor{&text}, &checker, 0, Limits{}, test.contentType)
+
+		if err != nil {
+			t.Errorf("test #%d: unexpected error %s", i, err)
+		}
+
+		if text != test.text {
+			t.Errorf("test #%d: expected '%s', got '%s'", i, test.text, text)
+		}
+	}
+}
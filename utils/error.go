@@ -12,6 +12,7 @@ var (
 	XMLSyntaxError                 = utils.InitFlag(&ErrorFlagCounter, "XMLSyntaxError")
 	XMLError                       = utils.Join("XMLError", XMLSyntaxError, XMLTokenError)
 	IOError                        = utils.InitFlag(&ErrorFlagCounter, "IOError")
+	Canceled                       = utils.InitFlag(&ErrorFlagCounter, "Canceled")
 )
 
 type ParserError interface {
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jloup/utils"
+	"golang.org/x/text/language"
+)
+
+var (
+	LanguageNotValid = utils.InitFlag(&ErrorFlagCounter, "LanguageNotValid")
+)
+
+// IsValidLanguage checks that s is a valid BCP 47 language tag (e.g. "en",
+// "en-US", "zh-Hant-TW"): well formed and made of registered subtags. The
+// empty string, which xml:lang uses to tell the language is unknown, is valid.
+func IsValidLanguage(name, s string) ParserError {
+	if s == "" {
+		return nil
+	}
+
+	// language.Parse also accepts '_' as separator
+	if strings.ContainsRune(s, '_') {
+		return NewError(LanguageNotValid, fmt.Sprintf("%s '%s' is not a valid BCP 47 language tag: subtags are separated by '-'", name, s))
+	}
+
+	if _, err := language.Parse(s); err != nil {
+		return NewError(LanguageNotValid, fmt.Sprintf("%s '%s' is not a valid BCP 47 language tag: %s", name, s, err))
+	}
+
+	return nil
+}
+
+// Direction is the writing direction of a script
+type Direction int
+
+const (
+	// UnknownDirection is used when there is no language
+	UnknownDirection Direction = iota
+	LeftToRight
+	RightToLeft
+)
+
+var directionNames = []string{"", "ltr", "rtl"}
+
+// String returns the value of the HTML dir attribute: "ltr", "rtl", or "" when
+// the direction is unknown
+func (d Direction) String() string {
+	if d < 0 || int(d) >= len(directionNames) {
+		return ""
+	}
+	return directionNames[d]
+}
+
+var rightToLeftScripts = map[string]bool{
+	"Adlm": true, "Arab": true, "Aran": true, "Hebr": true, "Mand": true,
+	"Mend": true, "Nkoo": true, "Rohg": true, "Samr": true, "Syrc": true,
+	"Thaa": true, "Yezi": true,
+}
+
+// LanguageDirection returns the writing direction of the language tag s. The
+// script is the one of s (e.g. "az-Arab"), or the most likely one for its
+// language (e.g. "ar" is written in Arabic, "az" in Latin).
+func LanguageDirection(s string) Direction {
+	if s == "" {
+		return UnknownDirection
+	}
+
+	tag, err := language.Parse(s)
+	if err != nil {
+		if _, ok := err.(language.ValueError); !ok {
+			return UnknownDirection
+		}
+	}
+
+	script, confidence := tag.Script()
+	if confidence == language.No {
+		return UnknownDirection
+	}
+
+	if rightToLeftScripts[script.String()] {
+		return RightToLeft
+	}
+	return LeftToRight
+}
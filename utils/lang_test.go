@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestIsValidLanguage(t *testing.T) {
+	for _, tag := range []string{"", "en", "en-US", "en-us", "fr-CA", "zh-Hant-TW", "sr-Latn", "x-private", "de-CH-1996"} {
+		if err := IsValidLanguage("lang", tag); err != nil {
+			t.Errorf("'%s' should be valid, got %s", tag, err)
+		}
+	}
+
+	for _, tag := range []string{"english", "en_US", "en-", "fr-CA-", "12", "zz-Abcd-XX"} {
+		err := IsValidLanguage("lang", tag)
+		if err == nil || !err.Flag().Cmp(LanguageNotValid) {
+			t.Errorf("'%s' should not be valid, got %v", tag, err)
+		}
+	}
+}
+
+func TestLanguageDirection(t *testing.T) {
+	var testdata = []struct {
+		Tag      string
+		Expected Direction
+	}{
+		{"", UnknownDirection},
+		{"not a tag", UnknownDirection},
+		{"en-US", LeftToRight},
+		{"ar", RightToLeft},
+		{"he-IL", RightToLeft},
+		{"fa", RightToLeft},
+		{"az", LeftToRight},
+		{"az-Arab", RightToLeft},
+		{"ar-Latn", LeftToRight},
+	}
+
+	for _, test := range testdata {
+		if d := LanguageDirection(test.Tag); d != test.Expected {
+			t.Errorf("'%s': expected '%s', got '%s'", test.Tag, test.Expected, d)
+		}
+	}
+}
+
+type langVisitor struct {
+	langs []string
+}
+
+func (v *langVisitor) ProcessStartElement(el StartElement) (Visitor, ParserError) {
+	v.langs = append(v.langs, el.Name.Local+":"+el.Lang)
+	return v, nil
+}
+
+func (v *langVisitor) ProcessEndElement(el xml.EndElement) (Visitor, ParserError) {
+	return v, nil
+}
+
+func (v *langVisitor) ProcessCharData(el xml.CharData) (Visitor, ParserError) {
+	return v, nil
+}
+
+func TestWalkLang(t *testing.T) {
+	doc := `<a><b xml:lang="fr"><c/><d xml:lang="ar"><e/></d><f xml:lang=""/></b><g/></a>`
+
+	v := langVisitor{}
+	checker := NewErrorChecker(EnableAllError)
+	if err := Walk(strings.NewReader(doc), &v, &checker, 0); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	expected := "a: b:fr c:fr d:ar e:ar f: g:"
+	if strings.Join(v.langs, " ") != expected {
+		t.Errorf("expected '%s', got '%s'", expected, strings.Join(v.langs, " "))
+	}
+}
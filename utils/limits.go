@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/jloup/utils"
+)
+
+var (
+	InputTooLarge          = utils.InitFlag(&ErrorFlagCounter, "InputTooLarge")
+	DepthLimitExceeded     = utils.InitFlag(&ErrorFlagCounter, "DepthLimitExceeded")
+	ElementLimitExceeded   = utils.InitFlag(&ErrorFlagCounter, "ElementLimitExceeded")
+	AttributeLimitExceeded = utils.InitFlag(&ErrorFlagCounter, "AttributeLimitExceeded")
+	TextLimitExceeded      = utils.InitFlag(&ErrorFlagCounter, "TextLimitExceeded")
+	ContentLimitExceeded   = utils.InitFlag(&ErrorFlagCounter, "ContentLimitExceeded")
+	LimitExceeded          = utils.Join("LimitExceeded", InputTooLarge, DepthLimitExceeded, ElementLimitExceeded, AttributeLimitExceeded, TextLimitExceeded, ContentLimitExceeded)
+)
+
+// Limits bounds the resources used to walk a document. A zero field means no
+// limit. Exceeding a limit always aborts the walk, whatever the FlagChecker
+// says.
+type Limits struct {
+	// MaxInputSize is the maximum number of bytes read from the input
+	MaxInputSize int64
+	// MaxDepth is the maximum nesting level of elements, the root being at 1
+	MaxDepth int
+	// MaxElements is the maximum number of elements visited in the document
+	MaxElements int
+	// MaxAttributes is the maximum number of attributes of a single element
+	MaxAttributes int
+	// MaxTextLength is the maximum length in bytes of a single character data
+	// token. It is checked once the token has been read: only MaxInputSize
+	// bounds the memory used to read it.
+	MaxTextLength int
+	// MaxContentLength is the maximum length in bytes of the content buffered by
+	// a visitor, e.g. inline XHTML content. It is enforced by the visitors
+	// through CheckContentLength.
+	MaxContentLength int
+}
+
+func limitError(flag utils.Flag, what string, max interface{}) ParserError {
+	return NewError(flag, fmt.Sprintf("%s exceeds limit of %v", what, max))
+}
+
+// CheckContentLength returns a ContentLimitExceeded error when a buffered
+// content of n bytes is over l.MaxContentLength. l may be nil.
+func (l *Limits) CheckContentLength(n int) ParserError {
+	if l != nil && l.MaxContentLength > 0 && n > l.MaxContentLength {
+		return limitError(ContentLimitExceeded, "content length", l.MaxContentLength)
+	}
+	return nil
+}
+
+// CheckInputSize returns an InputTooLarge error when an input of n bytes is
+// over l.MaxInputSize. l may be nil.
+func (l *Limits) CheckInputSize(n int64) ParserError {
+	if l != nil && l.MaxInputSize > 0 && n > l.MaxInputSize {
+		return limitError(InputTooLarge, "input size", l.MaxInputSize)
+	}
+	return nil
+}
+
+func (l *Limits) checkDepth(depth int) ParserError {
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return limitError(DepthLimitExceeded, "element depth", l.MaxDepth)
+	}
+	return nil
+}
+
+func (l *Limits) checkElements(count int) ParserError {
+	if l.MaxElements > 0 && count > l.MaxElements {
+		return limitError(ElementLimitExceeded, "number of elements", l.MaxElements)
+	}
+	return nil
+}
+
+func (l *Limits) checkAttributes(count int) ParserError {
+	if l.MaxAttributes > 0 && count > l.MaxAttributes {
+		return limitError(AttributeLimitExceeded, "number of attributes", l.MaxAttributes)
+	}
+	return nil
+}
+
+func (l *Limits) checkText(length int) ParserError {
+	if l.MaxTextLength > 0 && length > l.MaxTextLength {
+		return limitError(TextLimitExceeded, "text length", l.MaxTextLength)
+	}
+	return nil
+}
+
+// skip consumes the subtree of the element just started at depth, like
+// xml.Decoder.Skip, checking the limits on the elements and the text it holds.
+// Decoding errors are left to the next call to dec.Token.
+func (l *Limits) skip(dec *xml.Decoder, depth int, nbElements *int) ParserError {
+	for level := 0; ; {
+		t, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+
+		switch tt := t.(type) {
+		case xml.StartElement:
+			level++
+			*nbElements++
+			if err := l.checkElements(*nbElements); err != nil {
+				return err
+			}
+			if err := l.checkDepth(depth + level); err != nil {
+				return err
+			}
+			if err := l.checkAttributes(len(tt.Attr)); err != nil {
+				return err
+			}
+
+		case xml.EndElement:
+			if level == 0 {
+				return nil
+			}
+			level--
+
+		case xml.CharData:
+			if err := l.checkText(len(tt)); err != nil {
+				return err
+			}
+		}
+	}
+}
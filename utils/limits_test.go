@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/jloup/utils"
+)
+
+type nopVisitor struct{}
+
+func (v nopVisitor) ProcessStartElement(el StartElement) (Visitor, ParserError) { return v, nil }
+func (v nopVisitor) ProcessEndElement(el xml.EndElement) (Visitor, ParserError) { return v, nil }
+func (v nopVisitor) ProcessCharData(el xml.CharData) (Visitor, ParserError)     { return v, nil }
+
+// skipVisitor skips the elements named name
+type skipVisitor struct{ name string }
+
+func (v skipVisitor) ProcessStartElement(el StartElement) (Visitor, ParserError) {
+	if el.Name.Local == v.name {
+		return nil, nil
+	}
+	return v, nil
+}
+func (v skipVisitor) ProcessEndElement(el xml.EndElement) (Visitor, ParserError) { return v, nil }
+func (v skipVisitor) ProcessCharData(el xml.CharData) (Visitor, ParserError)     { return v, nil }
+
+const testLimitsXML = `<feed><entry a="1" b="2"><title>hello world</title></entry><entry><title>bye</title></entry></feed>`
+
+func TestWalkLimits(t *testing.T) {
+	tests := []struct {
+		limits   Limits
+		expected ParserError
+	}{
+		{Limits{}, nil},
+		{Limits{MaxInputSize: int64(len(testLimitsXML))}, nil},
+		{Limits{MaxInputSize: 10}, NewError(InputTooLarge, "")},
+		{Limits{MaxDepth: 3}, nil},
+		{Limits{MaxDepth: 2}, NewError(DepthLimitExceeded, "")},
+		{Limits{MaxElements: 5}, nil},
+		{Limits{MaxElements: 4}, NewError(ElementLimitExceeded, "")},
+		{Limits{MaxAttributes: 2}, nil},
+		{Limits{MaxAttributes: 1}, NewError(AttributeLimitExceeded, "")},
+		{Limits{MaxTextLength: 11}, nil},
+		{Limits{MaxTextLength: 10}, NewError(TextLimitExceeded, "")},
+	}
+
+	// limits are enforced even when error checking is disabled
+	checker := NewErrorChecker(DisableAllError)
+
+	// subtrees skipped by the visitor are checked as well
+	for _, v := range []Visitor{nopVisitor{}, skipVisitor{"entry"}} {
+		for i, test := range tests {
+			err := WalkContext(context.Background(), strings.NewReader(testLimitsXML), v, &checker, 0, test.limits, "")
+
+			if test.expected == nil {
+				if err != nil {
+					t.Errorf("test #%d (%T): unexpected error %s", i, v, err)
+				}
+				continue
+			}
+
+			if err == nil {
+				t.Errorf("test #%d (%T): expecting %s, got no error", i, v, test.expected.FlagString())
+				continue
+			}
+
+			if !err.Flag().Cmp(test.expected.Flag()) || !utils.Intersect(err.Flag(), LimitExceeded) {
+				t.Errorf("test #%d (%T): expecting %s, got %s", i, v, test.expected.FlagString(), err.FlagString())
+			}
+		}
+	}
+}
+
+func TestContentLength(t *testing.T) {
+	var l *Limits
+	if err := l.CheckContentLength(1 << 30); err != nil {
+		t.Errorf("nil limits should not limit content, got %s", err)
+	}
+
+	l = &Limits{MaxContentLength: 4}
+	if err := l.CheckContentLength(4); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	if err := l.CheckContentLength(5); err == nil || !err.Flag().Cmp(ContentLimitExceeded) {
+		t.Errorf("expecting ContentLimitExceeded, got %v", err)
+	}
+}
+
+func TestWalkCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	checker := NewErrorChecker(DisableAllError)
+	err := WalkContext(ctx, strings.NewReader(testLimitsXML), nopVisitor{}, &checker, 0, Limits{}, "")
+
+	if err == nil || !err.Flag().Cmp(Canceled) {
+		t.Errorf("expecting Canceled, got %v", err)
+	}
+}
@@ -34,6 +34,19 @@ func NewOccurenceCollection(occs ...*Occurence) OccurenceCollection {
 	return o
 }
 
+// Clone returns a collection holding copies of o's occurences, so that both
+// can be counted independently
+func (o *OccurenceCollection) Clone() OccurenceCollection {
+	c := OccurenceCollection{Occurences: make([]*Occurence, len(o.Occurences))}
+
+	for i, occ := range o.Occurences {
+		clone := *occ
+		c.Occurences[i] = &clone
+	}
+
+	return c
+}
+
 func (o *OccurenceCollection) AddOccurence(occ *Occurence) {
 	o.Occurences = append(o.Occurences, occ)
 }
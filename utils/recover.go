@@ -0,0 +1,297 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type RepairKind int
+
+const (
+	// ControlCharacter is a character forbidden by XML 1.0, or a character
+	// reference to it, which has been removed
+	ControlCharacter RepairKind = iota
+	// HTMLEntity is a HTML named entity (e.g. "&nbsp;") replaced by its
+	// character reference
+	HTMLEntity
+	// StrayAmpersand is a '&' which does not start a reference, escaped as
+	// "&amp;"
+	StrayAmpersand
+	// AutoClosedTag is an element left open inside its parent, closed just
+	// before the end tag of the parent
+	AutoClosedTag
+	// StrayEndTag is an end tag which does not close any open element, removed
+	StrayEndTag
+	// UnclosedTag is an element still open at the end of the document, closed
+	// at the end
+	UnclosedTag
+	// MismatchedEndTag is an end tag whose name differs in case from the
+	// element it closes, rewritten with the name of the start tag
+	MismatchedEndTag
+)
+
+var repairKindNames = []string{"ControlCharacter", "HTMLEntity", "StrayAmpersand", "AutoClosedTag", "StrayEndTag", "UnclosedTag", "MismatchedEndTag"}
+
+func (k RepairKind) String() string {
+	if k < 0 || int(k) >= len(repairKindNames) {
+		return "RepairKind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return repairKindNames[k]
+}
+
+// Repair records a change made by Recover
+type Repair struct {
+	Kind RepairKind
+	// Offset is the byte offset in the document given to Recover where the
+	// repair has been made. Documents are converted to UTF-8 beforehand (see
+	// ToUTF8): for other encodings, it is not an offset in the raw input.
+	Offset int
+	// Original is the text which has been replaced or removed, Replacement the
+	// text which has been written in its place
+	Original    string
+	Replacement string
+}
+
+// Recover repairs the usual mistakes of hand made feeds, so that they can be
+// walked by a strict XML decoder. It works in a single pass over the
+// characters and then over the tokens of b:
+//
+// - characters forbidden by XML 1.0 are removed
+// - HTML named entities are replaced by character references
+// - '&' not starting a reference is escaped
+// - elements left open are closed at the end of their parent, or of the
+// document, and end tags closing nothing are removed
+// - end tags differing in case from their start tag (<Title>x</title>) are
+// rewritten with the name of the start tag
+//
+// CDATA sections, comments and processing instructions are left untouched.
+// When the tokens can't be read up to the end of b (e.g. a syntax error in a
+// tag), the remaining bytes are copied as is. Every change is reported, in
+// document order.
+func Recover(b []byte) ([]byte, []Repair) {
+	r := recoverer{}
+
+	chars := r.recoverChars(b)
+	return r.recoverTokens(chars), r.repairs
+}
+
+type recoverer struct {
+	repairs []Repair
+
+	// shifts maps offsets of the document repaired by recoverChars back to
+	// offsets in the original document: at shifts[i].out, the original offset
+	// is shifts[i].in
+	shifts []offsetShift
+}
+
+type offsetShift struct {
+	out int
+	in  int
+}
+
+func (r *recoverer) originalOffset(out int) int {
+	i := sort.Search(len(r.shifts), func(i int) bool { return r.shifts[i].out > out })
+	if i == 0 {
+		return out
+	}
+
+	s := r.shifts[i-1]
+	return s.in + out - s.out
+}
+
+func (r *recoverer) addRepair(kind RepairKind, offset int, original, replacement string) {
+	r.repairs = append(r.repairs, Repair{Kind: kind, Offset: offset, Original: original, Replacement: replacement})
+}
+
+var untouchedSections = []struct{ start, end string }{
+	{"<![CDATA[", "]]>"},
+	{"<!--", "-->"},
+	{"<?", "?>"},
+}
+
+func (r *recoverer) recoverChars(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+
+	for i := 0; i < len(b); {
+		if end := untouchedSectionEnd(b[i:]); end > 0 {
+			out = append(out, b[i:i+end]...)
+			i += end
+			continue
+		}
+
+		c := b[i]
+		switch {
+		case c < 0x20 && c != '\t' && c != '\n' && c != '\r':
+			r.addRepair(ControlCharacter, i, string(c), "")
+			i++
+
+		case c == '&':
+			n, replacement, kind := checkReference(b[i:])
+			if kind < 0 {
+				out = append(out, b[i:i+n]...)
+			} else {
+				r.addRepair(kind, i, string(b[i:i+n]), replacement)
+				out = append(out, replacement...)
+			}
+			i += n
+
+		default:
+			out = append(out, c)
+			i++
+			continue
+		}
+
+		r.shifts = append(r.shifts, offsetShift{out: len(out), in: i})
+	}
+
+	return out
+}
+
+// untouchedSectionEnd returns the length of the section starting b which must
+// be copied as is, or 0
+func untouchedSectionEnd(b []byte) int {
+	for _, s := range untouchedSections {
+		if !bytes.HasPrefix(b, []byte(s.start)) {
+			continue
+		}
+
+		if end := bytes.Index(b[len(s.start):], []byte(s.end)); end != -1 {
+			return len(s.start) + end + len(s.end)
+		}
+		return len(b)
+	}
+
+	return 0
+}
+
+const maxEntityNameLength = 32
+
+// checkReference reads the reference starting b (with '&'), and returns its
+// length, and how to repair it. kind is negative when it is valid.
+func checkReference(b []byte) (n int, replacement string, kind RepairKind) {
+	end := bytes.IndexByte(b, ';')
+	if end == -1 || end > maxEntityNameLength {
+		return 1, "&amp;", StrayAmpersand
+	}
+	name := string(b[1:end])
+
+	if len(name) > 1 && name[0] == '#' {
+		var code uint64
+		var err error
+		if name[1] == 'x' {
+			code, err = strconv.ParseUint(name[2:], 16, 32)
+		} else {
+			code, err = strconv.ParseUint(name[1:], 10, 32)
+		}
+
+		switch {
+		case err != nil:
+			return 1, "&amp;", StrayAmpersand
+		case !isXMLChar(rune(code)):
+			return end + 1, "", ControlCharacter
+		}
+		return end + 1, "", -1
+	}
+
+	switch name {
+	case "lt", "gt", "amp", "quot", "apos":
+		return end + 1, "", -1
+	}
+
+	if text, ok := xml.HTMLEntity[name]; ok {
+		for _, c := range text {
+			replacement += "&#" + strconv.Itoa(int(c)) + ";"
+		}
+		return end + 1, replacement, HTMLEntity
+	}
+
+	return 1, "&amp;", StrayAmpersand
+}
+
+func isXMLChar(c rune) bool {
+	return c == '\t' || c == '\n' || c == '\r' ||
+		c >= 0x20 && c <= 0xD7FF ||
+		c >= 0xE000 && c <= 0xFFFD ||
+		c >= 0x10000 && c <= utf8.MaxRune
+}
+
+func rawName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+func (r *recoverer) recoverTokens(b []byte) []byte {
+	out := bytes.NewBuffer(make([]byte, 0, len(b)))
+
+	dec := xml.NewDecoder(bytes.NewReader(b))
+	// the encoding is only handled when walking the document, bytes are
+	// copied as is
+	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) { return input, nil }
+
+	var open []xml.Name
+	var prev int
+
+	for {
+		t, err := dec.RawToken()
+		if err != nil {
+			if err == io.EOF {
+				for i := len(open) - 1; i >= 0; i-- {
+					end := "</" + rawName(open[i]) + ">"
+					r.addRepair(UnclosedTag, r.originalOffset(prev), "", end)
+					out.WriteString(end)
+				}
+			}
+			out.Write(b[prev:])
+			return out.Bytes()
+		}
+		offset := int(dec.InputOffset())
+
+		switch tt := t.(type) {
+		case xml.StartElement:
+			open = append(open, tt.Name)
+
+		case xml.EndElement:
+			i := len(open) - 1
+			for ; i >= 0 && !sameName(open[i], tt.Name); i-- {
+			}
+
+			if i == -1 {
+				r.addRepair(StrayEndTag, r.originalOffset(prev), string(b[prev:offset]), "")
+				prev = offset
+				continue
+			}
+
+			for j := len(open) - 1; j > i; j-- {
+				end := "</" + rawName(open[j]) + ">"
+				r.addRepair(AutoClosedTag, r.originalOffset(prev), "", end)
+				out.WriteString(end)
+			}
+
+			if open[i] != tt.Name {
+				end := "</" + rawName(open[i]) + ">"
+				r.addRepair(MismatchedEndTag, r.originalOffset(prev), string(b[prev:offset]), end)
+				out.WriteString(end)
+				open = open[:i]
+				prev = offset
+				continue
+			}
+			open = open[:i]
+		}
+
+		out.Write(b[prev:offset])
+		prev = offset
+	}
+}
+
+// sameName compares element names case-insensitively, as hand made feeds
+// often close <Title> with </title>
+func sameName(a, b xml.Name) bool {
+	return strings.EqualFold(a.Space, b.Space) && strings.EqualFold(a.Local, b.Local)
+}
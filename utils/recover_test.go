@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		in       string
+		out      string
+		expected []Repair
+	}{
+		{
+			`<a>ok &amp; &#38; &#x26;</a>`,
+			`<a>ok &amp; &#38; &#x26;</a>`,
+			nil,
+		},
+		{
+			"<a>x\x01y&#1;</a>",
+			"<a>xy</a>",
+			[]Repair{{ControlCharacter, 4, "\x01", ""}, {ControlCharacter, 6, "&#1;", ""}},
+		},
+		{
+			`<a>a&nbsp;b &eacute;</a>`,
+			`<a>a&#160;b &#233;</a>`,
+			[]Repair{{HTMLEntity, 4, "&nbsp;", "&#160;"}, {HTMLEntity, 12, "&eacute;", "&#233;"}},
+		},
+		{
+			`<a href="http://x.org/?a=1&b=2">Q&A</a>`,
+			`<a href="http://x.org/?a=1&amp;b=2">Q&amp;A</a>`,
+			[]Repair{{StrayAmpersand, 26, "&", "&amp;"}, {StrayAmpersand, 33, "&", "&amp;"}},
+		},
+		{
+			`<a><![CDATA[a & b]]><!-- & --></a>`,
+			`<a><![CDATA[a & b]]><!-- & --></a>`,
+			nil,
+		},
+		{
+			`<a><b><c>x</a>`,
+			`<a><b><c>x</c></b></a>`,
+			[]Repair{{AutoClosedTag, 10, "", "</c>"}, {AutoClosedTag, 10, "", "</b>"}},
+		},
+		{
+			`<a><b/>x</p></a>`,
+			`<a><b/>x</a>`,
+			[]Repair{{StrayEndTag, 8, "</p>", ""}},
+		},
+		{
+			`<dc:a><b>&nbsp;`,
+			`<dc:a><b>&#160;</b></dc:a>`,
+			[]Repair{{HTMLEntity, 9, "&nbsp;", "&#160;"}, {UnclosedTag, 15, "", "</b>"}, {UnclosedTag, 15, "", "</dc:a>"}},
+		},
+		{
+			`<a>&nbsp;<b>x</c></a>`,
+			`<a>&#160;<b>x</b></a>`,
+			[]Repair{{HTMLEntity, 3, "&nbsp;", "&#160;"}, {StrayEndTag, 13, "</c>", ""}, {AutoClosedTag, 17, "", "</b>"}},
+		},
+		{
+			`<item><Title>x</title><link>y</link></item>`,
+			`<item><Title>x</Title><link>y</link></item>`,
+			[]Repair{{MismatchedEndTag, 14, "</title>", "</Title>"}},
+		},
+		{
+			`<a><DC:Title>x<b></dc:title><c/></a>`,
+			`<a><DC:Title>x<b></b></DC:Title><c/></a>`,
+			[]Repair{{AutoClosedTag, 17, "", "</b>"}, {MismatchedEndTag, 17, "</dc:title>", "</DC:Title>"}},
+		},
+		{
+			// syntax errors in tags are not repaired
+			`<a><b c=d></b></a>`,
+			`<a><b c=d></b></a>`,
+			nil,
+		},
+	}
+
+	for i, test := range tests {
+		out, repairs := Recover([]byte(test.in))
+
+		if string(out) != test.out {
+			t.Errorf("test #%d: expected '%s', got '%s'", i, test.out, out)
+		}
+
+		if !reflect.DeepEqual(repairs, test.expected) {
+			t.Errorf("test #%d: expected repairs %v, got %v", i, test.expected, repairs)
+		}
+	}
+}
+
+func TestRecoverWalk(t *testing.T) {
+	in := "<rss><channel><title>A&nbsp;&\x0cB</title><item><description>x<br>y</description></item></rss>"
+
+	out, _ := Recover([]byte(in))
+
+	checker := NewErrorChecker(EnableAllError)
+	if err := Walk(strings.NewReader(string(out)), nopVisitor{}, &checker, 0); err != nil {
+		t.Errorf("recovered document should be well formed, got %s", err)
+	}
+}
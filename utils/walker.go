@@ -3,27 +3,67 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"io"
 	"io/ioutil"
 	"strings"
 
+	"github.com/jloup/utils"
 	"golang.org/x/net/html/charset"
 )
 
 type StartElement struct {
 	*xml.StartElement
 	Ns *Namespaces
+	// Path is the slash separated list of the local names from the document
+	// root down to this element (e.g. "feed/entry/author")
+	Path string
+	// Limits are the limits of the walk, for visitors buffering content (see
+	// Limits.CheckContentLength)
+	Limits *Limits
+	// Lang is the language of this element: its xml:lang, or the one of its
+	// closest ancestor which has one. It is empty when unknown.
+	Lang string
 }
 
+// Walk visits the XML document read from r with v. The document is decoded
+// to UTF-8 first, whatever its encoding (see ToUTF8).
 func Walk(r io.Reader, v Visitor, custom FlagChecker, xmlTokenErrorRetry int) ParserError {
+	return WalkContext(context.Background(), r, v, custom, xmlTokenErrorRetry, Limits{}, "")
+}
+
+// WalkContext is like Walk, but aborts with a Canceled error once ctx is done,
+// and with a LimitExceeded error as soon as the document exceeds limits.
+// contentType (e.g. from HTTP headers) is used to detect the encoding of the
+// document, see DetectEncoding.
+func WalkContext(ctx context.Context, r io.Reader, v Visitor, custom FlagChecker, xmlTokenErrorRetry int, limits Limits, contentType string) ParserError {
+	if limits.MaxInputSize > 0 {
+		r = io.LimitReader(r, limits.MaxInputSize+1)
+	}
 
-	var err error
 	b, err := ioutil.ReadAll(r)
 	if err != nil {
 		return NewError(IOError, "Cannot read content")
 	}
 
+	if perr := limits.CheckInputSize(int64(len(b))); perr != nil {
+		return perr
+	}
+
+	b, _ = ToUTF8(b, contentType)
+
+	return WalkUTF8(ctx, b, v, custom, xmlTokenErrorRetry, limits)
+}
+
+// WalkUTF8 is like WalkContext, for a document already read and converted to
+// UTF-8 (see ToUTF8). limits.MaxInputSize is not checked.
+func WalkUTF8(ctx context.Context, b []byte, v Visitor, custom FlagChecker, xmlTokenErrorRetry int, limits Limits) ParserError {
+	var err error
+	var r io.Reader
+
+	done := ctx.Done()
+
 	for {
 
 		b = bytes.TrimSpace(b)
@@ -38,10 +78,19 @@ func Walk(r io.Reader, v Visitor, custom FlagChecker, xmlTokenErrorRetry int) Pa
 		var perr ParserError
 		var tokenName string
 		var element StartElement
+		var path []string
+		var langs []string
+		var nbElements int
 		namespaces := Namespaces{}
 
 		for {
 
+			select {
+			case <-done:
+				return NewError(Canceled, ctx.Err().Error())
+			default:
+			}
+
 			if t, err = dec.Token(); err != nil {
 				if err == io.EOF {
 					return nil
@@ -70,18 +119,42 @@ func Walk(r io.Reader, v Visitor, custom FlagChecker, xmlTokenErrorRetry int) Pa
 				tokenName = tt.Name.Local
 				namespaces.Inc(tt.Name.Space)
 
-				element = StartElement{&tt, &namespaces}
+				nbElements++
+				if perr = limits.checkElements(nbElements); perr != nil {
+					return perr
+				}
+				if perr = limits.checkDepth(len(path) + 1); perr != nil {
+					return perr
+				}
+				if perr = limits.checkAttributes(len(tt.Attr)); perr != nil {
+					return perr
+				}
+
+				element = StartElement{StartElement: &tt, Ns: &namespaces, Limits: &limits}
 				element.Name.Space = strings.ToLower(tt.Name.Space)
 				element.Name.Local = strings.ToLower(tt.Name.Local)
+				path = append(path, element.Name.Local)
+				element.Path = strings.Join(path, "/")
+				if len(langs) > 0 {
+					element.Lang = langs[len(langs)-1]
+				}
 				for i, _ := range element.Attr {
 					element.Attr[i].Name.Space = strings.ToLower(element.Attr[i].Name.Space)
 					element.Attr[i].Name.Local = strings.ToLower(element.Attr[i].Name.Local)
+					if element.Attr[i].Name.Space == XML_NS && element.Attr[i].Name.Local == "lang" {
+						element.Lang = element.Attr[i].Value
+					}
 				}
+				langs = append(langs, element.Lang)
 				startVisitor, perr = v.ProcessStartElement(element)
 
 				if startVisitor == nil {
 					startOffset = dec.InputOffset()
-					dec.Skip()
+					if perr = limits.skip(dec, len(path), &nbElements); perr != nil {
+						return perr
+					}
+					path = path[:len(path)-1]
+					langs = langs[:len(langs)-1]
 				} else {
 					startOffset = dec.InputOffset()
 					v = startVisitor
@@ -90,13 +163,24 @@ func Walk(r io.Reader, v Visitor, custom FlagChecker, xmlTokenErrorRetry int) Pa
 			case xml.EndElement:
 				tokenName = tt.Name.Local
 				namespaces.Dec(tt.Name.Space)
+				if len(path) > 0 {
+					path = path[:len(path)-1]
+					langs = langs[:len(langs)-1]
+				}
 				v, perr = v.ProcessEndElement(tt)
 				startOffset = dec.InputOffset()
 			case xml.CharData:
+				if perr = limits.checkText(len(tt)); perr != nil {
+					return perr
+				}
 				v, perr = v.ProcessCharData(tt)
 				startOffset = dec.InputOffset()
 			}
 
+			if perr != nil && utils.Intersect(perr.Flag(), LimitExceeded) {
+				return &delegatedError{delegatedError: perr, tokenName: tokenName}
+			}
+
 			if perr != nil && custom.CheckFlag(tokenName, perr) {
 				return &delegatedError{delegatedError: custom.ErrorWithCode(tokenName, perr), tokenName: tokenName}
 			}